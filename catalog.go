@@ -0,0 +1,204 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	internal "github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// subsystems maps names of subsystems to details of SDK traces
+var subsystems = map[string]trace.Details{
+	"driver":                     trace.DriverEvents,
+	"driver.net":                 trace.DriverNetEvents,
+	"driver.conn":                trace.DriverConnEvents,
+	"driver.balancer":            trace.DriverBalancerEvents,
+	"driver.repeater":            trace.DriverRepeaterEvents,
+	"driver.credentials":         trace.DriverCredentialsEvents,
+	"table":                      trace.TableEvents,
+	"table.session":              trace.TableSessionEvents,
+	"table.session.lifecycle":    trace.TableSessionLifeCycleEvents,
+	"table.session.query":        trace.TableSessionQueryEvents,
+	"table.session.query.invoke": trace.TableSessionQueryInvokeEvents,
+	"table.session.query.stream": trace.TableSessionQueryStreamEvents,
+	"table.session.transaction":  trace.TableSessionTransactionEvents,
+	"table.pool":                 trace.TablePoolEvents,
+	"table.pool.lifecycle":       trace.TablePoolLifeCycleEvents,
+	"table.pool.session":         trace.TablePoolSessionLifeCycleEvents,
+	"table.pool.api":             trace.TablePoolAPIEvents,
+	"database.sql":               trace.DatabaseSQLEvents,
+	"database.sql.connector":     trace.DatabaseSQLConnectorEvents,
+	"database.sql.conn":          trace.DatabaseSQLConnEvents,
+	"database.sql.tx":            trace.DatabaseSQLTxEvents,
+	"database.sql.stmt":          trace.DatabaseSQLStmtEvents,
+	"retry":                      trace.RetryEvents,
+	"discovery":                  trace.DiscoveryEvents,
+	"scripting":                  trace.ScriptingEvents,
+	"scheme":                     trace.SchemeEvents,
+	"coordination":               trace.CoordinationEvents,
+	"ratelimiter":                trace.RatelimiterEvents,
+	"topic":                      trace.TopicEvents,
+	"topic.reader":               trace.TopicReaderEvents,
+	"topic.reader.lifecycle":     trace.TopicReaderStreamLifeCycleEvents,
+	"topic.reader.partition":     trace.TopicReaderPartitionEvents,
+	"topic.reader.stream":        trace.TopicReaderStreamEvents,
+	"topic.reader.message":       trace.TopicReaderMessageEvents,
+}
+
+// scopes maps full paths of all scopes to details which enable scope
+var scopes = map[string]trace.Details{
+	"driver.repeater":                    trace.DriverRepeaterEvents,
+	"driver.net.read":                    trace.DriverNetEvents,
+	"driver.net.read.bytes":              trace.DriverNetEvents,
+	"driver.net.write":                   trace.DriverNetEvents,
+	"driver.net.write.bytes":             trace.DriverNetEvents,
+	"driver.net.dial":                    trace.DriverNetEvents,
+	"driver.net.close":                   trace.DriverNetEvents,
+	"driver.conn.take":                   trace.DriverConnEvents,
+	"driver.conn.invoke":                 trace.DriverConnEvents,
+	"driver.conn.stream":                 trace.DriverConnEvents,
+	"driver.conn.state":                  trace.DriverConnEvents,
+	"driver.conn.park":                   trace.DriverConnEvents,
+	"driver.conn.close":                  trace.DriverConnEvents,
+	"driver.balancer.init":               trace.DriverBalancerEvents,
+	"driver.balancer.close":              trace.DriverBalancerEvents,
+	"driver.balancer.update":             trace.DriverBalancerEvents,
+	"driver.balancer.chooseEndpoint":     trace.DriverBalancerEvents,
+	"driver.credentials.get":             trace.DriverCredentialsEvents,
+	"table.createSession":                trace.TableEvents,
+	"table.do":                           trace.TableEvents,
+	"table.do_tx":                        trace.TableEvents,
+	"table.pool.max":                     trace.TableEvents,
+	"table.pool.size":                    trace.TablePoolLifeCycleEvents,
+	"table.pool.session.add":             trace.TablePoolSessionLifeCycleEvents,
+	"table.pool.session.remove":          trace.TablePoolSessionLifeCycleEvents,
+	"table.pool.put":                     trace.TablePoolAPIEvents,
+	"table.pool.get":                     trace.TablePoolAPIEvents,
+	"table.pool.wait":                    trace.TablePoolAPIEvents,
+	"table.session.new":                  trace.TableSessionLifeCycleEvents,
+	"table.session.delete":               trace.TableSessionLifeCycleEvents,
+	"table.session.keep_alive":           trace.TableSessionLifeCycleEvents,
+	"table.session.query.invoke.prepare": trace.TableSessionQueryInvokeEvents,
+	"table.session.query.invoke.execute": trace.TableSessionQueryInvokeEvents,
+	"table.session.query.stream.read":    trace.TableSessionQueryStreamEvents,
+	"table.session.query.stream.execute": trace.TableSessionQueryStreamEvents,
+	"table.session.transaction.begin":    trace.TableSessionTransactionEvents,
+	"table.session.transaction.commit":   trace.TableSessionTransactionEvents,
+	"table.session.transaction.rollback": trace.TableSessionTransactionEvents,
+	"database.sql.connector.connect":     trace.DatabaseSQLConnectorEvents,
+	"database.sql.conn.ping":             trace.DatabaseSQLConnEvents,
+	"database.sql.conn.close":            trace.DatabaseSQLConnEvents,
+	"database.sql.conn.begin":            trace.DatabaseSQLConnEvents,
+	"database.sql.conn.prepare":          trace.DatabaseSQLConnEvents,
+	"database.sql.conn.exec":             trace.DatabaseSQLConnEvents,
+	"database.sql.conn.query":            trace.DatabaseSQLConnEvents,
+	"database.sql.tx.commit":             trace.DatabaseSQLTxEvents,
+	"database.sql.tx.rollback":           trace.DatabaseSQLTxEvents,
+	"database.sql.tx.query":              trace.DatabaseSQLTxEvents,
+	"database.sql.tx.exec":               trace.DatabaseSQLTxEvents,
+	"database.sql.stmt.close":            trace.DatabaseSQLStmtEvents,
+	"database.sql.stmt.exec":             trace.DatabaseSQLStmtEvents,
+	"database.sql.stmt.query":            trace.DatabaseSQLStmtEvents,
+	"retry":                              trace.RetryEvents,
+	"retry.attempts":                     trace.RetryEvents,
+	"discovery":                          trace.DiscoveryEvents,
+	"scripting.execute":                  trace.ScriptingEvents,
+	"scripting.explain":                  trace.ScriptingEvents,
+	"coordination.node.create":           trace.CoordinationEvents,
+	"coordination.node.alter":            trace.CoordinationEvents,
+	"coordination.node.drop":             trace.CoordinationEvents,
+	"coordination.node.describe":         trace.CoordinationEvents,
+	"ratelimiter.resource.create":        trace.RatelimiterEvents,
+	"ratelimiter.resource.alter":         trace.RatelimiterEvents,
+	"ratelimiter.resource.drop":          trace.RatelimiterEvents,
+	"ratelimiter.resource.list":          trace.RatelimiterEvents,
+	"ratelimiter.resource.describe":      trace.RatelimiterEvents,
+	"ratelimiter.acquire":                trace.RatelimiterEvents,
+	"scheme.list_directory":              trace.SchemeEvents,
+	"scheme.describe_path":               trace.SchemeEvents,
+	"scheme.make_directory":              trace.SchemeEvents,
+	"scheme.remove_directory":            trace.SchemeEvents,
+	"scheme.modify_permissions":          trace.SchemeEvents,
+	"topic.reader.init":                  trace.TopicReaderStreamLifeCycleEvents,
+	"topic.reader.close":                 trace.TopicReaderStreamLifeCycleEvents,
+	"topic.reader.reconnect":             trace.TopicReaderStreamLifeCycleEvents,
+	"topic.reader.reconnect_request":     trace.TopicReaderStreamLifeCycleEvents,
+	"topic.reader.partition.start":       trace.TopicReaderPartitionEvents,
+	"topic.reader.partition.stop":        trace.TopicReaderPartitionEvents,
+	"topic.reader.partition.active":      trace.TopicReaderPartitionEvents,
+	"topic.reader.commit":                trace.TopicReaderStreamEvents,
+	"topic.reader.receive":               trace.TopicReaderStreamEvents,
+	"topic.reader.receive.messages":      trace.TopicReaderStreamEvents,
+	"topic.reader.receive.batches":       trace.TopicReaderStreamEvents,
+	"topic.reader.buffer":                trace.TopicReaderStreamEvents,
+	"topic.reader.read":                  trace.TopicReaderMessageEvents,
+	"topic.reader.lag":                   trace.TopicReaderMessageEvents,
+	"scripting.stream.execute":           trace.ScriptingEvents,
+}
+
+var (
+	scopeTagsOnce sync.Once
+	// scopeTags maps full paths of scopes to tags of scopes, see discoverTags
+	scopeTags map[string][]string
+)
+
+// discoverTags returns tags of all scopes by full paths of scopes
+// Tags discovers once by making all traces and clients, so tags are always in sync with scopes
+func discoverTags() map[string][]string {
+	scopeTagsOnce.Do(func() {
+		scopeTags = internal.Discover(func(c registry.Config) {
+			WithTraces(c)
+			CoordinationClient(c, nil)
+			SchemeClient(c, nil)
+			RatelimiterClient(c, nil)
+			TopicReadLag(c)
+		})
+	})
+	return scopeTags
+}
+
+// knownTags returns sorted tags of scope by path or tags of all nested scopes by path of subsystem
+// Caller tag is known for all scopes, see WithCallerLabel
+func knownTags(path string) []string {
+	known := map[string]struct{}{
+		labels.TagCaller: {},
+	}
+	for s, tags := range discoverTags() {
+		if s == path || strings.HasPrefix(s, path+".") {
+			for _, tag := range tags {
+				known[tag] = struct{}{}
+			}
+		}
+	}
+	tags := make([]string, 0, len(known))
+	for tag := range known {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// knownScope reports whether path is a path of scope or a path of subsystem with scopes
+func knownScope(path string) bool {
+	for s := range scopes {
+		if s == path || strings.HasPrefix(s, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// subsystemNames returns sorted names of known subsystems
+func subsystemNames() []string {
+	names := make([]string, 0, len(subsystems))
+	for name := range subsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDiscoverTags(t *testing.T) {
+	discovered := discoverTags()
+	var missed, unknown []string
+	for path := range scopes {
+		if _, has := discovered[path]; !has {
+			missed = append(missed, path)
+		}
+	}
+	for path := range discovered {
+		if _, has := scopes[path]; !has {
+			unknown = append(unknown, path)
+		}
+	}
+	sort.Strings(missed)
+	sort.Strings(unknown)
+	if len(missed) > 0 {
+		t.Errorf("scopes of catalog not made by traces: %v", missed)
+	}
+	if len(unknown) > 0 {
+		t.Errorf("scopes of traces not in catalog: %v", unknown)
+	}
+}
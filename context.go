@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/ctxlabels"
+)
+
+// WithLabels returns copy of parent context with labels for metrics of calls with this context
+// Labels used only by scopes which allows them with WithContextLabels option
+func WithLabels(ctx context.Context, labels map[string]string) context.Context {
+	return ctxlabels.With(ctx, labels)
+}
+
+// contextOf returns context from trace start info
+// SDK provides pointer to context for replacing context inside trace callback
+func contextOf(ctx *context.Context) context.Context {
+	if ctx == nil || *ctx == nil {
+		return context.Background()
+	}
+	return *ctx
+}
@@ -1,10 +1,80 @@
 package metrics
 
 import (
-	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination"
+	"github.com/ydb-platform/ydb-go-sdk/v3/scheme"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
 )
 
+// Coordination makes trace.Coordination
+// trace.Coordination of SDK has no hooks, use CoordinationClient for measuring coordination client calls
 func Coordination(c registry.Config) (t trace.Coordination) {
 	return t
 }
+
+// CoordinationClient wraps client for measuring calls, errors and latency of coordination nodes operations
+// Coordination client of SDK has no sessions and semaphores, so only nodes operations are measured
+func CoordinationClient(c registry.Config, client coordination.Client, opts ...Option) coordination.Client {
+	c = withOptions(c, opts...)
+	if c.Details()&trace.CoordinationEvents == 0 {
+		return client
+	}
+	c = c.WithSystem("coordination").WithSystem("node")
+	return &coordinationClient{
+		Client:   client,
+		create:   scope.New(c, "create", config.New(config.WithDescription("creating coordination node"))),
+		alter:    scope.New(c, "alter", config.New(config.WithDescription("altering coordination node"))),
+		drop:     scope.New(c, "drop", config.New(config.WithDescription("dropping coordination node"))),
+		describe: scope.New(c, "describe", config.New(config.WithDescription("describing coordination node"))),
+	}
+}
+
+type coordinationClient struct {
+	coordination.Client
+
+	create   scope.Scope
+	alter    scope.Scope
+	drop     scope.Scope
+	describe scope.Scope
+}
+
+func (c *coordinationClient) CreateNode(ctx context.Context, path string, cfg coordination.NodeConfig) (err error) {
+	start := c.create.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.CreateNode(ctx, path, cfg)
+}
+
+func (c *coordinationClient) AlterNode(ctx context.Context, path string, cfg coordination.NodeConfig) (err error) {
+	start := c.alter.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.AlterNode(ctx, path, cfg)
+}
+
+func (c *coordinationClient) DropNode(ctx context.Context, path string) (err error) {
+	start := c.drop.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.DropNode(ctx, path)
+}
+
+func (c *coordinationClient) DescribeNode(
+	ctx context.Context,
+	path string,
+) (_ *scheme.Entry, _ *coordination.NodeConfig, err error) {
+	start := c.describe.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.DescribeNode(ctx, path)
+}
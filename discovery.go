@@ -13,11 +13,16 @@ func Discovery(c registry.Config) (t trace.Discovery) {
 	if c.Details()&trace.DiscoveryEvents != 0 {
 		discovery := scope.New(c, "discovery",
 			config.New(
+				config.WithDescription("discovery of cluster endpoints"),
+				config.WithValueDescription("number of discovered endpoints"),
 				config.WithValue(config.ValueTypeGauge),
 			),
 			labels.TagAddress,
 		)
 		t.OnDiscover = func(info trace.DiscoveryDiscoverStartInfo) func(trace.DiscoveryDiscoverDoneInfo) {
+			if !discovery.Enabled() {
+				return nil
+			}
 			address := labels.Label{
 				Tag:   labels.TagAddress,
 				Value: info.Address,
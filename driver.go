@@ -29,11 +29,14 @@ var (
 )
 
 // Driver makes Driver with New publishing
-func Driver(c registry.Config) (t trace.Driver) {
-	c = c.WithSystem("driver")
+func Driver(c registry.Config, opts ...Option) (t trace.Driver) {
+	c = withOptions(c, opts...).WithSystem("driver")
 	if c.Details()&trace.DriverRepeaterEvents != 0 {
-		repeater := scope.New(c, "repeater", config.New(), labels.TagMethod, labels.TagName)
+		repeater := scope.New(c, "repeater", config.New(config.WithDescription("driver repeater wake up")), labels.TagMethod, labels.TagName)
 		t.OnRepeaterWakeUp = func(info trace.DriverRepeaterWakeUpStartInfo) func(trace.DriverRepeaterWakeUpDoneInfo) {
+			if !repeater.Enabled() {
+				return nil
+			}
 			name := labels.Label{
 				Tag:   labels.TagName,
 				Value: info.Name,
@@ -48,15 +51,109 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 	}
+	if c.Details()&trace.DriverNetEvents != 0 {
+		c := c.WithSystem("net")
+		read := scope.New(c, "read", config.New(
+			config.WithDescription("reading from network connection"),
+			config.WithValueDescription("bytes per read"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValue(config.ValueTypeHistogram),
+			config.WithValueBuckets(bytesBuckets),
+			config.WithoutLatency(),
+		), labels.TagAddress)
+		readBytes := scope.New(c.WithSystem("read"), "bytes", config.New(
+			config.WithDescription("bytes read from network connections"),
+			config.WithValueDescription("total bytes read"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValueOnly(config.ValueTypeCounter),
+		), labels.TagAddress)
+		write := scope.New(c, "write", config.New(
+			config.WithDescription("writing to network connection"),
+			config.WithValueDescription("bytes per write"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValue(config.ValueTypeHistogram),
+			config.WithValueBuckets(bytesBuckets),
+			config.WithoutLatency(),
+		), labels.TagAddress)
+		writeBytes := scope.New(c.WithSystem("write"), "bytes", config.New(
+			config.WithDescription("bytes written to network connections"),
+			config.WithValueDescription("total bytes written"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValueOnly(config.ValueTypeCounter),
+		), labels.TagAddress)
+		dial := scope.New(c, "dial", config.New(config.WithDescription("dialing network connection")), labels.TagAddress)
+		close := scope.New(c, "close", config.New(config.WithDescription("closing network connection")), labels.TagAddress)
+		t.OnNetRead = func(info trace.DriverNetReadStartInfo) func(trace.DriverNetReadDoneInfo) {
+			if !read.Enabled() && !readBytes.Enabled() {
+				return nil
+			}
+			address := labels.Label{
+				Tag:   labels.TagAddress,
+				Value: info.Address,
+			}
+			start := read.Start(address)
+			return func(info trace.DriverNetReadDoneInfo) {
+				start.SyncWithValue(info.Error, float64(info.Received), address)
+				if info.Received > 0 {
+					readBytes.Start().SyncValue(float64(info.Received), address)
+				}
+			}
+		}
+		t.OnNetWrite = func(info trace.DriverNetWriteStartInfo) func(trace.DriverNetWriteDoneInfo) {
+			if !write.Enabled() && !writeBytes.Enabled() {
+				return nil
+			}
+			address := labels.Label{
+				Tag:   labels.TagAddress,
+				Value: info.Address,
+			}
+			start := write.Start(address)
+			return func(info trace.DriverNetWriteDoneInfo) {
+				start.SyncWithValue(info.Error, float64(info.Sent), address)
+				if info.Sent > 0 {
+					writeBytes.Start().SyncValue(float64(info.Sent), address)
+				}
+			}
+		}
+		t.OnNetDial = func(info trace.DriverNetDialStartInfo) func(trace.DriverNetDialDoneInfo) {
+			if !dial.Enabled() {
+				return nil
+			}
+			address := labels.Label{
+				Tag:   labels.TagAddress,
+				Value: info.Address,
+			}
+			start := dial.Start(address)
+			return func(info trace.DriverNetDialDoneInfo) {
+				start.Sync(info.Error, address)
+			}
+		}
+		t.OnNetClose = func(info trace.DriverNetCloseStartInfo) func(trace.DriverNetCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
+			address := labels.Label{
+				Tag:   labels.TagAddress,
+				Value: info.Address,
+			}
+			start := close.Start(address)
+			return func(info trace.DriverNetCloseDoneInfo) {
+				start.Sync(info.Error, address)
+			}
+		}
+	}
 	if c.Details()&trace.DriverConnEvents != 0 {
 		c := c.WithSystem("conn")
-		take := scope.New(c, "take", config.New(), labels.TagAddress)
-		invoke := scope.New(c, "invoke", config.New(), labels.TagAddress, labels.TagMethod)
-		stream := scope.New(c, "stream", config.New(), labels.TagAddress, labels.TagMethod, labels.TagStage)
-		states := scope.New(c, "state", config.New(), labels.TagAddress, labels.TagState)
-		park := scope.New(c, "park", config.New(), labels.TagAddress)
-		close := scope.New(c, "close", config.New(), labels.TagAddress)
+		take := scope.New(c, "take", config.New(config.WithDescription("taking connection to endpoint")), labels.TagAddress)
+		invoke := scope.New(c, "invoke", config.New(config.WithDescription("unary call over connection")), labels.TagAddress, labels.TagMethod)
+		stream := scope.New(c, "stream", config.New(config.WithDescription("streaming call over connection"), config.WithStages()), labels.TagAddress, labels.TagMethod, labels.TagStage)
+		states := scope.New(c, "state", config.New(config.WithDescription("connection state change"), config.WithDoneTags(labels.TagState)), labels.TagAddress, labels.TagState)
+		park := scope.New(c, "park", config.New(config.WithDescription("parking idle connection")), labels.TagAddress)
+		close := scope.New(c, "close", config.New(config.WithDescription("closing connection")), labels.TagAddress)
 		t.OnConnTake = func(info trace.DriverConnTakeStartInfo) func(trace.DriverConnTakeDoneInfo) {
+			if !take.Enabled() {
+				return nil
+			}
 			address := labels.Label{
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
@@ -67,14 +164,14 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 		t.OnConnStateChange = func(info trace.DriverConnStateChangeStartInfo) func(trace.DriverConnStateChangeDoneInfo) {
+			if !states.Enabled() {
+				return nil
+			}
 			address := labels.Label{
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
 			}
-			start := states.Start(address, labels.Label{
-				Tag:   labels.TagState,
-				Value: info.State.String(),
-			})
+			start := states.Start(address)
 			return func(info trace.DriverConnStateChangeDoneInfo) {
 				start.Sync(nil, address, labels.Label{
 					Tag:   labels.TagState,
@@ -83,6 +180,9 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 		t.OnConnInvoke = func(info trace.DriverConnInvokeStartInfo) func(trace.DriverConnInvokeDoneInfo) {
+			if !invoke.Enabled() {
+				return nil
+			}
 			method := labels.Label{
 				Tag:   labels.TagMethod,
 				Value: string(info.Method),
@@ -91,12 +191,15 @@ func Driver(c registry.Config) (t trace.Driver) {
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
 			}
-			start := invoke.Start(address, method)
+			start := invoke.StartWithContext(contextOf(info.Context), address, method)
 			return func(info trace.DriverConnInvokeDoneInfo) {
 				start.Sync(info.Error, address, method)
 			}
 		}
 		t.OnConnNewStream = func(info trace.DriverConnNewStreamStartInfo) func(trace.DriverConnNewStreamRecvInfo) func(trace.DriverConnNewStreamDoneInfo) {
+			if !stream.Enabled() {
+				return nil
+			}
 			method := labels.Label{
 				Tag:   labels.TagMethod,
 				Value: string(info.Method),
@@ -105,12 +208,9 @@ func Driver(c registry.Config) (t trace.Driver) {
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
 			}
-			start := stream.Start(address, method, labels.Label{
-				Tag:   labels.TagStage,
-				Value: "init",
-			})
+			start := stream.StartWithContext(contextOf(info.Context), address, method)
 			return func(info trace.DriverConnNewStreamRecvInfo) func(trace.DriverConnNewStreamDoneInfo) {
-				start.Sync(info.Error, address, method, labels.Label{
+				start.Intermediate(info.Error, address, method, labels.Label{
 					Tag:   labels.TagStage,
 					Value: "intermediate",
 				})
@@ -123,6 +223,9 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 		t.OnConnPark = func(info trace.DriverConnParkStartInfo) func(trace.DriverConnParkDoneInfo) {
+			if !park.Enabled() {
+				return nil
+			}
 			address := labels.Label{
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
@@ -133,6 +236,9 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 		t.OnConnClose = func(info trace.DriverConnCloseStartInfo) func(trace.DriverConnCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
 			address := labels.Label{
 				Tag:   labels.TagAddress,
 				Value: info.Endpoint.Address(),
@@ -146,38 +252,50 @@ func Driver(c registry.Config) (t trace.Driver) {
 	if c.Details()&trace.DriverBalancerEvents != 0 {
 		c := c.WithSystem("balancer")
 		init := scope.New(c, "init", config.New(
+			config.WithDescription("balancer initialization"),
 			config.WithoutCalls(),
 			config.WithoutError(),
 		))
 		close := scope.New(c, "close", config.New(
+			config.WithDescription("balancer closing"),
 			config.WithoutCalls(),
 		))
 		update := scope.New(c, "update",
 			config.New(
+				config.WithDescription("balancer update"),
+				config.WithValueDescription("number of endpoints"),
+				config.WithDoneTags(labels.TagDataCenter),
 				config.WithValue(config.ValueTypeGauge),
 			),
 			labels.TagDataCenter,
 		)
-		choose := scope.New(c, "chooseEndpoint", config.New(), labels.TagAddress, labels.TagDataCenter)
+		choose := scope.New(c, "chooseEndpoint", config.New(
+			config.WithDescription("balancer choosing endpoint"),
+			config.WithDoneTags(labels.TagAddress, labels.TagDataCenter),
+		), labels.TagAddress, labels.TagDataCenter)
 		t.OnBalancerInit = func(info trace.DriverBalancerInitStartInfo) func(trace.DriverBalancerInitDoneInfo) {
+			if !init.Enabled() {
+				return nil
+			}
 			start := init.Start()
 			return func(info trace.DriverBalancerInitDoneInfo) {
 				start.Sync(nil)
 			}
 		}
 		t.OnBalancerClose = func(info trace.DriverBalancerCloseStartInfo) func(trace.DriverBalancerCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
 			start := close.Start()
 			return func(info trace.DriverBalancerCloseDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnBalancerUpdate = func(info trace.DriverBalancerUpdateStartInfo) func(trace.DriverBalancerUpdateDoneInfo) {
-			start := update.Start(
-				labels.Label{
-					Tag:   labels.TagDataCenter,
-					Value: "wip",
-				},
-			)
+			if !update.Enabled() {
+				return nil
+			}
+			start := update.Start()
 			return func(info trace.DriverBalancerUpdateDoneInfo) {
 				start.SyncWithValue(info.Error, float64(len(info.Endpoints)),
 					labels.Label{
@@ -188,16 +306,10 @@ func Driver(c registry.Config) (t trace.Driver) {
 			}
 		}
 		t.OnBalancerChooseEndpoint = func(info trace.DriverBalancerChooseEndpointStartInfo) func(trace.DriverBalancerChooseEndpointDoneInfo) {
-			start := choose.Start(
-				labels.Label{
-					Tag:   labels.TagAddress,
-					Value: "wip",
-				},
-				labels.Label{
-					Tag:   labels.TagDataCenter,
-					Value: "wip",
-				},
-			)
+			if !choose.Enabled() {
+				return nil
+			}
+			start := choose.Start()
 			return func(info trace.DriverBalancerChooseEndpointDoneInfo) {
 				if info.Error == nil {
 					start.Sync(
@@ -227,8 +339,11 @@ func Driver(c registry.Config) (t trace.Driver) {
 	}
 	if c.Details()&trace.DriverCredentialsEvents != 0 {
 		c := c.WithSystem("credentials")
-		get := scope.New(c, "get", config.New())
+		get := scope.New(c, "get", config.New(config.WithDescription("getting credentials")))
 		t.OnGetCredentials = func(info trace.DriverGetCredentialsStartInfo) func(trace.DriverGetCredentialsDoneInfo) {
+			if !get.Enabled() {
+				return nil
+			}
 			start := get.Start()
 			return func(info trace.DriverGetCredentialsDoneInfo) {
 				start.Sync(info.Error)
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// EnvDetails is a name of environment variable with details of metrics traces
+const EnvDetails = "YDB_METRICS_DETAILS"
+
+// ParseDetails parses comma-separated list of subsystems and scopes, such as
+// "driver.conn,table.pool,-table.session.query.stream,-driver.conn.park"
+// Items applies from left to right to empty mask, or to base mask if s has no positive items,
+// so "-driver.conn.park" only disables scope of base details. Name of subsystem appends details of subsystem
+// to mask, name with "-" prefix removes details of subsystem from mask. Path of scope appends
+// details which enable scope, path of scope with "-" prefix disables scope.
+// Special name "all" means all subsystems
+// ParseDetails returns details mask and paths of disabled scopes
+func ParseDetails(s string, base trace.Details) (details trace.Details, disabled []string, _ error) {
+	var (
+		issues []string
+		items  = strings.Split(s, ",")
+	)
+	if !positive(items) {
+		details = base
+	}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name := strings.TrimPrefix(item, "-")
+		exclude := name != item
+		if name == "all" {
+			if exclude {
+				details = 0
+			} else {
+				details = trace.DetailsAll
+			}
+			continue
+		}
+		if d, has := subsystems[name]; has {
+			if exclude {
+				details &^= d
+			} else {
+				details |= d
+			}
+			continue
+		}
+		if !knownScope(name) {
+			issues = append(issues, unknownName(name))
+			continue
+		}
+		if exclude {
+			disabled = append(disabled, name)
+			continue
+		}
+		for path, d := range scopes {
+			if path == name || strings.HasPrefix(path, name+".") {
+				details |= d
+			}
+		}
+	}
+	if len(issues) > 0 {
+		return 0, nil, fmt.Errorf("metrics: invalid details %q: %s", s, strings.Join(issues, "; "))
+	}
+	return details, disabled, nil
+}
+
+// FromEnv wraps c with details and disabled scopes from EnvDetails environment variable
+// FromEnv returns c with details of c if environment variable is not set
+// Scopes disabled by environment variable stays disabled if options of WithTraces applies to returned config
+func FromEnv(c registry.Config) (registry.Config, error) {
+	s, has := os.LookupEnv(EnvDetails)
+	if !has {
+		return c, nil
+	}
+	details, disabled, err := ParseDetails(s, c.Details())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EnvDetails, err)
+	}
+	return withOptions(c, WithDetails(details), WithoutScopes(disabled...)), nil
+}
+
+// positive reports whether items has names which appends details
+func positive(items []string) bool {
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" && !strings.HasPrefix(item, "-") {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownName describes unknown name of subsystem or scope with suggestion of known name
+func unknownName(name string) string {
+	return unknown("subsystem or scope", name, knownNames())
+}
+
+// unknown describes unknown name of kind with suggestion of closest known name
+func unknown(kind, name string, known []string) string {
+	var (
+		suggestion string
+		best       = len(name)/2 + 1
+	)
+	for _, k := range known {
+		if d := distance(name, k); d < best {
+			suggestion, best = k, d
+		}
+	}
+	if suggestion == "" {
+		return fmt.Sprintf("unknown %s %q", kind, name)
+	}
+	return fmt.Sprintf("unknown %s %q, did you mean %q?", kind, name, suggestion)
+}
+
+// knownNames returns sorted names of known subsystems and paths of known scopes
+func knownNames() []string {
+	names := subsystemNames()
+	for path := range scopes {
+		if _, has := subsystems[path]; !has {
+			names = append(names, path)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// distance returns Levenshtein distance between a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minOf(v int, vv ...int) int {
+	for _, x := range vv {
+		if x < v {
+			v = x
+		}
+	}
+	return v
+}
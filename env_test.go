@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/registrytest"
+	internal "github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+)
+
+func TestParseDetails(t *testing.T) {
+	for _, tt := range []struct {
+		s        string
+		base     trace.Details
+		details  trace.Details
+		disabled []string
+	}{
+		{
+			s:       "",
+			base:    trace.DriverConnEvents,
+			details: trace.DriverConnEvents,
+		},
+		{
+			s:        "-driver.conn.park",
+			base:     trace.DriverConnEvents | trace.TableEvents,
+			details:  trace.DriverConnEvents | trace.TableEvents,
+			disabled: []string{"driver.conn.park"},
+		},
+		{
+			s:       "-table",
+			base:    trace.DriverConnEvents | trace.TableEvents,
+			details: trace.DriverConnEvents,
+		},
+		{
+			s:       "driver.conn, table.pool",
+			base:    trace.TableEvents,
+			details: trace.DriverConnEvents | trace.TablePoolEvents,
+		},
+		{
+			s:       "table,-table.session.query.stream",
+			details: trace.TableEvents &^ trace.TableSessionQueryStreamEvents,
+		},
+		{
+			s:       "all,-driver",
+			details: trace.DetailsAll &^ trace.DriverEvents,
+		},
+		{
+			s:       "driver.conn,-all,retry",
+			details: trace.RetryEvents,
+		},
+		{
+			s:       "table.do",
+			details: trace.TableEvents,
+		},
+		{
+			s:        "driver.conn,-driver.conn.park",
+			details:  trace.DriverConnEvents,
+			disabled: []string{"driver.conn.park"},
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			details, disabled, err := ParseDetails(tt.s, tt.base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details != tt.details {
+				t.Errorf("unexpected details: %b, expected %b", details, tt.details)
+			}
+			if !reflect.DeepEqual(disabled, tt.disabled) {
+				t.Errorf("unexpected disabled scopes: %v, expected %v", disabled, tt.disabled)
+			}
+		})
+	}
+}
+
+func TestParseDetailsUnknown(t *testing.T) {
+	for _, tt := range []struct {
+		s       string
+		message string
+	}{
+		{
+			s:       "driver.con",
+			message: `unknown subsystem or scope "driver.con", did you mean "driver.conn"?`,
+		},
+		{
+			s:       "table,-tabel.do",
+			message: `unknown subsystem or scope "tabel.do", did you mean "table.do"?`,
+		},
+		{
+			s:       "qwertyuiop",
+			message: `unknown subsystem or scope "qwertyuiop"`,
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			_, _, err := ParseDetails(tt.s, 0)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.HasSuffix(err.Error(), tt.message) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFromEnvWithOptions(t *testing.T) {
+	if err := os.Setenv(EnvDetails, "driver.conn,-driver.conn.park"); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(EnvDetails)
+	c, err := FromEnv(registrytest.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRuntime()
+	c = withOptions(c, WithRuntime(r), WithRetryBudget(2, time.Minute)).WithSystem("driver").WithSystem("conn")
+	for _, name := range []string{"park", "take"} {
+		internal.New(c, name, config.New())
+	}
+	expected := []ScopeState{
+		{
+			Path:  "driver.conn.park",
+			Fixed: true,
+		},
+		{
+			Path:    "driver.conn.take",
+			Enabled: true,
+		},
+	}
+	if states := r.Scopes(); !reflect.DeepEqual(states, expected) {
+		t.Errorf("unexpected scopes: %+v, expected %+v", states, expected)
+	}
+	if err := r.Enable("driver.conn"); err == nil {
+		t.Error("expected error on enabling of disabled by configuration scope")
+	}
+}
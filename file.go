@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+)
+
+// fileConfig is a declarative configuration of metrics
+//
+// Example:
+//
+//	details: [driver.conn, table, database.sql]
+//	const_labels:
+//	  service: orders
+//	scopes:
+//	  driver.conn.park:
+//	    enabled: false
+//	  table.do:
+//	    buckets: [1, 2, 5, 10]
+//	    labels: [idempotent, stage]
+//	    context_labels: [handler]
+//	    sampling: 0.1
+type fileConfig struct {
+	Details     []string                   `yaml:"details"`
+	ConstLabels map[string]string          `yaml:"const_labels"`
+	Scopes      map[string]fileScopeConfig `yaml:"scopes"`
+}
+
+type fileScopeConfig struct {
+	Enabled       *bool     `yaml:"enabled"`
+	Calls         *bool     `yaml:"calls"`
+	Errors        *bool     `yaml:"errors"`
+	Latency       *bool     `yaml:"latency"`
+	Buckets       []float64 `yaml:"buckets"`
+	Labels        []string  `yaml:"labels"`
+	ContextLabels []string  `yaml:"context_labels"`
+	Sampling      *float64  `yaml:"sampling"`
+}
+
+// LoadConfig reads YAML or JSON configuration file and returns options for WithTraces
+func LoadConfig(path string) ([]Option, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: cannot read config %q: %w", path, err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses YAML or JSON configuration document and returns options for WithTraces
+// Document validates against catalog of known subsystems, scopes and tags of scopes
+func ParseConfig(data []byte) ([]Option, error) {
+	var cfg fileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("metrics: cannot parse config: %w", err)
+	}
+	return cfg.options()
+}
+
+func (cfg *fileConfig) options() (opts []Option, _ error) {
+	var issues []string
+	if cfg.Details != nil {
+		var details trace.Details
+		for _, name := range cfg.Details {
+			d, ok := subsystems[name]
+			if !ok {
+				issues = append(issues, fmt.Sprintf("unknown subsystem %q (known: %s)",
+					name, strings.Join(subsystemNames(), ", "),
+				))
+				continue
+			}
+			details |= d
+		}
+		opts = append(opts, WithDetails(details))
+	}
+	if len(cfg.ConstLabels) > 0 {
+		opts = append(opts, WithConstLabels(cfg.ConstLabels))
+	}
+	paths := make([]string, 0, len(cfg.Scopes))
+	for path := range cfg.Scopes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if !knownScope(path) {
+			issues = append(issues, unknown("scope path", path, knownNames()))
+			continue
+		}
+		for _, name := range cfg.unknownLabels(path) {
+			issues = append(issues, fmt.Sprintf("scope %q: %s", path, name))
+		}
+		scopeOpts, err := cfg.Scopes[path].options()
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("scope %q: %v", path, err))
+			continue
+		}
+		opts = append(opts, withScope(path, scopeOpts...))
+	}
+	if len(issues) > 0 {
+		return nil, fmt.Errorf("metrics: invalid config: %s", strings.Join(issues, "; "))
+	}
+	return opts, nil
+}
+
+// unknownLabels describes names of labels allowlist of scope by path which are not tags of scope,
+// context labels of scope or its parent subsystems
+func (cfg *fileConfig) unknownLabels(path string) (issues []string) {
+	labels := cfg.Scopes[path].Labels
+	if len(labels) == 0 {
+		return nil
+	}
+	known := knownTags(path)
+	for prefix, s := range cfg.Scopes {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			known = append(known, s.ContextLabels...)
+		}
+	}
+	for _, name := range labels {
+		if !contains(known, name) {
+			issues = append(issues, unknown("label", name, known))
+		}
+	}
+	return issues
+}
+
+func (s fileScopeConfig) options() (opts []config.Option, _ error) {
+	if s.Enabled != nil {
+		if *s.Enabled {
+			opts = append(opts, config.Enabled())
+		} else {
+			opts = append(opts, config.Disabled())
+		}
+	}
+	if s.Calls != nil && !*s.Calls {
+		opts = append(opts, config.WithoutCalls())
+	}
+	if s.Errors != nil && !*s.Errors {
+		opts = append(opts, config.WithoutError())
+	}
+	if s.Latency != nil && !*s.Latency {
+		opts = append(opts, config.WithoutLatency())
+	}
+	if s.Buckets != nil {
+		if !sort.Float64sAreSorted(s.Buckets) {
+			return nil, fmt.Errorf("buckets must be sorted: %v", s.Buckets)
+		}
+		opts = append(opts, config.WithValueBuckets(s.Buckets))
+	}
+	if s.Labels != nil {
+		opts = append(opts, config.WithAllowedTags(s.Labels...))
+	}
+	if len(s.ContextLabels) > 0 {
+		opts = append(opts, config.WithContextLabels(s.ContextLabels...))
+	}
+	if s.Sampling != nil {
+		if *s.Sampling < 0 || *s.Sampling > 1 {
+			return nil, fmt.Errorf("sampling must be in range [0, 1]: %v", *s.Sampling)
+		}
+		opts = append(opts, config.WithSamplingRate(*s.Sampling))
+	}
+	return opts, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+)
+
+func TestParseConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		data    string
+		details *trace.Details
+		scopes  []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "yaml",
+			data: `
+details: [driver.conn, table]
+const_labels:
+  service: orders
+scopes:
+  driver.conn.park:
+    enabled: false
+  table.do:
+    buckets: [1, 2, 5, 10]
+    labels: [idempotent, stage, handler]
+    context_labels: [handler]
+    sampling: 0.1
+`,
+			details: func() *trace.Details {
+				d := trace.DriverConnEvents | trace.TableEvents
+				return &d
+			}(),
+			scopes: []string{"", "driver.conn.park", "table.do"},
+		},
+		{
+			name:   "json",
+			data:   `{"scopes": {"table.session": {"latency": false, "calls": false, "errors": false}}}`,
+			scopes: []string{"table.session"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseConfig([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			o := &options{
+				scopes: make(map[string][]config.Option),
+			}
+			for _, opt := range opts {
+				opt(o)
+			}
+			if !reflect.DeepEqual(o.details, tt.details) {
+				t.Errorf("unexpected details: %v, expected %v", o.details, tt.details)
+			}
+			var scopes []string
+			for path := range o.scopes {
+				scopes = append(scopes, path)
+			}
+			sort.Strings(scopes)
+			if !reflect.DeepEqual(scopes, tt.scopes) {
+				t.Errorf("unexpected scopes: %v, expected %v", scopes, tt.scopes)
+			}
+		})
+	}
+}
+
+func TestParseConfigScope(t *testing.T) {
+	opts, err := ParseConfig([]byte(`
+scopes:
+  table.do:
+    errors: false
+    sampling: 0.5
+  driver.conn:
+    enabled: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &options{
+		scopes: make(map[string][]config.Option),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	do := config.With(config.New(), o.overrides("table.do")...)
+	if do.HasError() || !do.HasCalls() || do.SamplingRate() != 0.5 {
+		t.Errorf("unexpected config of table.do: error %v, calls %v, sampling %v",
+			do.HasError(), do.HasCalls(), do.SamplingRate(),
+		)
+	}
+	if park := config.With(config.New(), o.overrides("driver.conn.park")...); !park.Disabled() {
+		t.Error("driver.conn.park must be disabled by driver.conn")
+	}
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		data    string
+		message string
+	}{
+		{
+			name:    "unknown field",
+			data:    "detail: [driver]",
+			message: "field detail not found",
+		},
+		{
+			name:    "unknown subsystem",
+			data:    "details: [drive]",
+			message: `unknown subsystem "drive"`,
+		},
+		{
+			name:    "unknown scope",
+			data:    "scopes: {table.dox: {enabled: false}}",
+			message: `unknown scope path "table.dox", did you mean "table.do"?`,
+		},
+		{
+			name:    "unknown label",
+			data:    "scopes: {table.do: {labels: [idempotnt]}}",
+			message: `scope "table.do": unknown label "idempotnt", did you mean "idempotent"?`,
+		},
+		{
+			name:    "label of other scope",
+			data:    "scopes: {driver.conn: {labels: [idempotent]}}",
+			message: `scope "driver.conn": unknown label "idempotent"`,
+		},
+		{
+			name:    "unsorted buckets",
+			data:    "scopes: {table.do: {buckets: [5, 1]}}",
+			message: `scope "table.do": buckets must be sorted`,
+		},
+		{
+			name:    "sampling",
+			data:    "scopes: {table.do: {sampling: 2}}",
+			message: `scope "table.do": sampling must be in range [0, 1]`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.data))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
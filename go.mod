@@ -2,4 +2,10 @@ module github.com/ydb-platform/ydb-go-sdk-metrics
 
 go 1.16
 
-require github.com/ydb-platform/ydb-go-sdk/v3 v3.35.1
+require (
+	github.com/ydb-platform/ydb-go-genproto v0.0.0-20220801095836-cf975531fd1f
+	github.com/ydb-platform/ydb-go-sdk/v3 v3.35.1
+	go.opentelemetry.io/otel/trace v1.7.0
+	google.golang.org/grpc v1.47.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,209 @@
+package amplification
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+const (
+	tagWindow = "window"
+
+	// bucketsPerWindow is a granularity of rolling window
+	bucketsPerWindow = 10
+)
+
+// DefaultWindows are rolling windows of Tracker if windows not specified
+var DefaultWindows = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+}
+
+// Tracker computes retry amplification: total attempts divided by logical operations
+// over rolling windows per idempotency class
+// Gauges of windows recomputes on every bucket of shortest window while windows has operations,
+// so gauges of idempotency class decays to zero after class stops getting operations
+type Tracker struct {
+	mu            sync.Mutex
+	budget        float64
+	durations     []time.Duration
+	interval      time.Duration
+	background    bool
+	ticking       bool
+	windows       map[string][]*window
+	amplification registry.GaugeVec
+	exhausted     registry.CounterVec
+}
+
+// New makes Tracker with amplification gauges and budget_exhausted counter in c
+// Zero budget disables budget_exhausted counter
+func New(c registry.Config, budget float64, windows ...time.Duration) *Tracker {
+	return newTracker(c, budget, true, windows...)
+}
+
+// newTracker makes Tracker which decays gauges in background goroutine if background is true,
+// otherwise gauges decays only by explicit calls of decay
+func newTracker(c registry.Config, budget float64, background bool, windows ...time.Duration) *Tracker {
+	if len(windows) == 0 {
+		windows = DefaultWindows
+	}
+	interval := windows[0]
+	for _, d := range windows[1:] {
+		if d < interval {
+			interval = d
+		}
+	}
+	interval /= bucketsPerWindow
+	if interval <= 0 {
+		interval = 1
+	}
+	return &Tracker{
+		budget:     budget,
+		durations:  windows,
+		interval:   interval,
+		background: background,
+		windows:    make(map[string][]*window),
+		amplification: c.GaugeVec(registry.Opts{
+			Name:      "amplification",
+			Help:      "retry amplification: attempts per logical operation over rolling window",
+			Stability: registry.StabilityExperimental,
+		}, labels.TagIdempotent, tagWindow),
+		exhausted: c.CounterVec(registry.Opts{
+			Name:      "budget_exhausted",
+			Help:      "number of operations finished while retry amplification exceeds retry budget",
+			Stability: registry.StabilityExperimental,
+		}, labels.TagIdempotent, tagWindow),
+	}
+}
+
+// Observe accounts finished logical operation with number of attempts
+func (t *Tracker) Observe(idempotent string, attempts int) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	windows, has := t.windows[idempotent]
+	if !has {
+		windows = make([]*window, 0, len(t.durations))
+		for _, d := range t.durations {
+			windows = append(windows, newWindow(d))
+		}
+		t.windows[idempotent] = windows
+	}
+	for _, w := range windows {
+		w.add(now, attempts)
+		kv := map[string]string{
+			labels.TagIdempotent: idempotent,
+			tagWindow:            w.name,
+		}
+		ratio, _ := w.ratio(now)
+		t.amplification.With(kv).Set(ratio)
+		if t.budget > 0 && ratio > t.budget {
+			t.exhausted.With(kv).Inc()
+		}
+	}
+	if t.background && !t.ticking {
+		t.ticking = true
+		go t.tick()
+	}
+}
+
+// tick recomputes gauges until all windows expires
+func (t *Tracker) tick() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if !t.decay(now) {
+			return
+		}
+	}
+}
+
+// decay recomputes gauges of all windows and reports whether any window has operations
+func (t *Tracker) decay(now time.Time) (active bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for idempotent, windows := range t.windows {
+		for _, w := range windows {
+			ratio, operations := w.ratio(now)
+			t.amplification.With(map[string]string{
+				labels.TagIdempotent: idempotent,
+				tagWindow:            w.name,
+			}).Set(ratio)
+			if operations > 0 {
+				active = true
+			}
+		}
+	}
+	t.ticking = active
+	return active
+}
+
+type bucket struct {
+	epoch      int64
+	operations float64
+	attempts   float64
+}
+
+// window accumulates operations and attempts over rolling window of buckets
+type window struct {
+	name    string
+	width   time.Duration
+	buckets [bucketsPerWindow]bucket
+}
+
+func newWindow(d time.Duration) *window {
+	width := d / bucketsPerWindow
+	if width <= 0 {
+		width = 1
+	}
+	return &window{
+		name:  name(d),
+		width: width,
+	}
+}
+
+func (w *window) add(now time.Time, attempts int) {
+	epoch := now.UnixNano() / int64(w.width)
+	b := &w.buckets[epoch%bucketsPerWindow]
+	if b.epoch != epoch {
+		*b = bucket{epoch: epoch}
+	}
+	b.operations++
+	b.attempts += float64(attempts)
+}
+
+// ratio returns attempts per operation and number of operations over window
+func (w *window) ratio(now time.Time) (_ float64, operations float64) {
+	var (
+		epoch    = now.UnixNano() / int64(w.width)
+		attempts float64
+	)
+	for _, b := range w.buckets {
+		if epoch-b.epoch < bucketsPerWindow {
+			operations += b.operations
+			attempts += b.attempts
+		}
+	}
+	if operations == 0 {
+		return 0, 0
+	}
+	return attempts / operations, operations
+}
+
+// name returns short name of window duration, such as "5m"
+func name(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%ds", d/time.Second)
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,71 @@
+package amplification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/registrytest"
+)
+
+func TestWindow(t *testing.T) {
+	var (
+		w   = newWindow(time.Minute)
+		now = time.Unix(1000, 0)
+	)
+	w.add(now, 1)
+	w.add(now.Add(10*time.Second), 3)
+	if ratio, operations := w.ratio(now.Add(20 * time.Second)); ratio != 2 || operations != 2 {
+		t.Errorf("unexpected ratio: %v of %v operations", ratio, operations)
+	}
+	// first operation expires after window
+	if ratio, operations := w.ratio(now.Add(65 * time.Second)); ratio != 3 || operations != 1 {
+		t.Errorf("unexpected ratio: %v of %v operations", ratio, operations)
+	}
+	if ratio, operations := w.ratio(now.Add(2 * time.Minute)); ratio != 0 || operations != 0 {
+		t.Errorf("unexpected ratio of expired window: %v of %v operations", ratio, operations)
+	}
+}
+
+func TestName(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		time.Minute:             "1m",
+		15 * time.Minute:        "15m",
+		2 * time.Hour:           "2h",
+		30 * time.Second:        "30s",
+		1500 * time.Millisecond: "1.5s",
+	} {
+		if got := name(d); got != expected {
+			t.Errorf("unexpected name of %v: %q, expected %q", d, got, expected)
+		}
+	}
+}
+
+func TestTracker(t *testing.T) {
+	var (
+		c  = registrytest.New()
+		kv = map[string]string{
+			labels.TagIdempotent: "true",
+			tagWindow:            "1m",
+		}
+	)
+	tracker := newTracker(c, 1.5, false, time.Minute)
+	tracker.Observe("true", 1)
+	tracker.Observe("true", 3)
+	if got := c.Value("amplification", kv); got != 2 {
+		t.Errorf("unexpected amplification: %v", got)
+	}
+	// first operation of window has amplification under budget
+	if got := c.Value("budget_exhausted", kv); got != 1 {
+		t.Errorf("unexpected budget exhausted: %v", got)
+	}
+	if !tracker.decay(time.Now()) {
+		t.Error("window with operations must be active")
+	}
+	if tracker.decay(time.Now().Add(2 * time.Minute)) {
+		t.Error("expired window must be inactive")
+	}
+	if got := c.Value("amplification", kv); got != 0 {
+		t.Errorf("amplification of expired window not decayed: %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package bounded
+
+import "sync"
+
+// Other is a label value for values over limit of distinct values
+const Other = "other"
+
+// Values caps number of distinct label values
+type Values struct {
+	limit int
+
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+// New makes Values with cap of distinct values
+// Values over limit resolves to Other
+func New(limit int) *Values {
+	return &Values{
+		limit:  limit,
+		values: make(map[string]struct{}, limit),
+	}
+}
+
+// Value returns v if v is one of first limit distinct values or Other
+func (b *Values) Value(v string) string {
+	b.mu.RLock()
+	_, ok := b.values[v]
+	b.mu.RUnlock()
+	if ok {
+		return v
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.values[v]; ok {
+		return v
+	}
+	if len(b.values) >= b.limit {
+		return Other
+	}
+	b.values[v] = struct{}{}
+	return v
+}
@@ -0,0 +1,113 @@
+package caller
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	// Other is a caller name for callers over limit of distinct callers
+	Other = "other"
+
+	// Unknown is a caller name if all frames of stack belongs to SDK
+	Unknown = "unknown"
+
+	maxDepth = 64
+)
+
+var skipPrefixes = []string{
+	"github.com/ydb-platform/ydb-go-sdk/",
+	"github.com/ydb-platform/ydb-go-sdk-metrics/",
+	// functions and closures of root package, such as ydb-go-sdk-metrics.Table.func1
+	"github.com/ydb-platform/ydb-go-sdk-metrics.",
+	"runtime.",
+}
+
+// Resolver resolves name of function which calls SDK
+// Resolved program counters are cached, so stack walking costs only runtime.Callers
+// on hot path
+type Resolver struct {
+	limit int
+	pcs   sync.Map // uintptr -> string, empty string for skipped frames
+
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+// New makes Resolver with cap of distinct caller names
+// Callers over limit resolves to Other
+func New(limit int) *Resolver {
+	return &Resolver{
+		limit: limit,
+		names: make(map[string]struct{}, limit),
+	}
+}
+
+// Caller returns name of first function in current stack outside of SDK and metrics packages
+func (r *Resolver) Caller() string {
+	var pcs [maxDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		if name := r.resolve(pc); name != "" {
+			return name
+		}
+	}
+	return Unknown
+}
+
+func (r *Resolver) resolve(pc uintptr) string {
+	if name, ok := r.pcs.Load(pc); ok {
+		return name.(string)
+	}
+	// pc of inlined calls has frames of all inlined functions
+	name := ""
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if !skip(frame.Function) {
+			name = r.bound(short(frame.Function))
+			break
+		}
+		if !more {
+			break
+		}
+	}
+	r.pcs.Store(pc, name)
+	return name
+}
+
+// bound applies cap of distinct caller names
+func (r *Resolver) bound(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.names[name]; ok {
+		return name
+	}
+	if len(r.names) >= r.limit {
+		return Other
+	}
+	r.names[name] = struct{}{}
+	return name
+}
+
+func skip(function string) bool {
+	if function == "" {
+		return true
+	}
+	for _, prefix := range skipPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// short trims package path of function name
+// github.com/org/service/repo.(*Repo).Get -> repo.(*Repo).Get
+func short(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		return function[i+1:]
+	}
+	return function
+}
@@ -0,0 +1,31 @@
+package ctxlabels
+
+import (
+	"context"
+)
+
+type ctxLabelsKey struct{}
+
+// With returns copy of parent context with labels
+// Labels merges with labels of parent context, labels from arguments has priority
+func With(ctx context.Context, labels map[string]string) context.Context {
+	merged := make(map[string]string, len(labels))
+	for k, v := range From(ctx) {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, ctxLabelsKey{}, merged)
+}
+
+// From returns labels attached to context
+func From(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	if labels, ok := ctx.Value(ctxLabelsKey{}).(map[string]string); ok {
+		return labels
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package errclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+)
+
+const (
+	SideClient = "client"
+	SideServer = "server"
+
+	RetryableFalse   = "false"
+	RetryableTrue    = "true"
+	RetryableBackoff = "backoff"
+)
+
+// Class is a classification of error
+// Empty Retryable, Deadline and Side fields detects by SDK helpers
+type Class struct {
+	// Error is a value of error label, such as "transport/UNAVAILABLE"
+	Error string
+	// Code is a value of errCode label
+	Code string
+	// Retryable is "true" for errors retryable immediately, "backoff" for errors
+	// retryable with backoff and "false" for non-retryable errors
+	Retryable string
+	// Deadline is "true" for deadline-related errors
+	Deadline string
+	// Side is "client" for errors raised by client and "server" for errors returned by server
+	Side string
+	// GRPCCode is a canonical gRPC code of error, such as "Unavailable", or "none"
+	// for errors without gRPC status
+	GRPCCode string
+}
+
+// Classifier classifies error
+// Classifier returns false if err is not known by classifier and next classifier must be used
+type Classifier func(err error) (c Class, ok bool)
+
+// Defaults is an ordered list of builtin classifiers
+// Last classifier classifies all errors as unknown
+var Defaults = []Classifier{
+	network,
+	eof,
+	deadline,
+	canceled,
+	transport,
+	operation,
+	grpcStatus,
+	ydbError,
+	unknown,
+}
+
+// Sink receives classified errors with raw error messages which not used as labels
+type Sink func(c Class, err error)
+
+// Labels appends error labels of err to lbls
+// classifiers applies in order before Defaults
+func Labels(classifiers []Classifier, err error, lbls ...labels.Label) []labels.Label {
+	return Classify(classifiers, err).Labels(lbls...)
+}
+
+// Labels appends error labels of c to lbls
+func (c Class) Labels(lbls ...labels.Label) []labels.Label {
+	return append(lbls,
+		labels.Label{
+			Tag:   labels.TagError,
+			Value: c.Error,
+		},
+		labels.Label{
+			Tag:   labels.TagErrCode,
+			Value: c.Code,
+		},
+		labels.Label{
+			Tag:   labels.TagRetryable,
+			Value: c.Retryable,
+		},
+		labels.Label{
+			Tag:   labels.TagDeadline,
+			Value: c.Deadline,
+		},
+		labels.Label{
+			Tag:   labels.TagSide,
+			Value: c.Side,
+		},
+		labels.Label{
+			Tag:   labels.TagGRPCCode,
+			Value: c.GRPCCode,
+		},
+	)
+}
+
+// Classify classifies err with first classifier which knows err
+// classifiers applies in order before Defaults
+func Classify(classifiers []Classifier, err error) Class {
+	if c, ok := first(classifiers, err); ok {
+		return complete(c, err)
+	}
+	c, _ := first(Defaults, err)
+	return complete(c, err)
+}
+
+func first(classifiers []Classifier, err error) (Class, bool) {
+	for _, classify := range classifiers {
+		if c, ok := classify(err); ok {
+			return c, true
+		}
+	}
+	return Class{}, false
+}
+
+// complete fills empty fields of c
+func complete(c Class, err error) Class {
+	if c.Error == "" {
+		c.Error = "unknown"
+	}
+	if c.Code == "" {
+		c.Code = "-1"
+	}
+	if c.Retryable == "" {
+		c.Retryable = retryable(err)
+	}
+	if c.Deadline == "" {
+		c.Deadline = strconv.FormatBool(errors.Is(err, context.DeadlineExceeded) || ydb.IsTimeoutError(err))
+	}
+	if c.Side == "" {
+		c.Side = SideClient
+	}
+	if c.GRPCCode == "" {
+		c.GRPCCode = "none"
+		if s, ok := statusOf(err); ok {
+			c.GRPCCode = s.Code().String()
+		}
+	}
+	return c
+}
+
+// statusOf returns gRPC status of err or of any error in chain of err
+func statusOf(err error) (*status.Status, bool) {
+	if s, ok := status.FromError(err); ok && s != nil {
+		return s, true
+	}
+	var e interface {
+		GRPCStatus() *status.Status
+	}
+	if errors.As(err, &e) && e.GRPCStatus() != nil {
+		return e.GRPCStatus(), true
+	}
+	return nil, false
+}
+
+// grpcCategories maps substrings of gRPC status messages to bounded set of message categories
+var grpcCategories = []struct {
+	category   string
+	substrings []string
+}{
+	{"connection_refused", []string{"connection refused"}},
+	{"connection_reset", []string{"connection reset", "broken pipe"}},
+	{"connection_closed", []string{"transport is closing", "connection closed", "eof"}},
+	{"deadline", []string{"deadline exceeded"}},
+	{"canceled", []string{"context canceled"}},
+	{"dns", []string{"no such host", "name resolver", "produced zero addresses"}},
+	{"tls", []string{"tls:", "x509:", "handshake"}},
+	{"message_size", []string{"larger than max"}},
+	{"auth", []string{"token", "credentials", "unauthenticated"}},
+}
+
+// grpcCategory returns category of gRPC status message
+func grpcCategory(message string) string {
+	if message == "" {
+		return "none"
+	}
+	message = strings.ToLower(message)
+	for _, c := range grpcCategories {
+		for _, substring := range c.substrings {
+			if strings.Contains(message, substring) {
+				return c.category
+			}
+		}
+	}
+	return "other"
+}
+
+func retryable(err error) string {
+	m := retry.Check(err)
+	switch {
+	case !m.MustRetry(true):
+		return RetryableFalse
+	case m.MustBackoff():
+		return RetryableBackoff
+	default:
+		return RetryableTrue
+	}
+}
+
+func eof(err error) (Class, bool) {
+	if !errors.Is(err, io.EOF) {
+		return Class{}, false
+	}
+	return Class{
+		Error: "io/EOF",
+		Code:  "-1",
+		Side:  SideClient,
+	}, true
+}
+
+func deadline(err error) (Class, bool) {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return Class{}, false
+	}
+	return Class{
+		Error:    "context/DeadlineExceeded",
+		Code:     "-1",
+		Deadline: "true",
+		Side:     SideClient,
+	}, true
+}
+
+func canceled(err error) (Class, bool) {
+	if !errors.Is(err, context.Canceled) {
+		return Class{}, false
+	}
+	return Class{
+		Error:    "context/Canceled",
+		Code:     "-1",
+		Deadline: "false",
+		Side:     SideClient,
+	}, true
+}
+
+func transport(err error) (Class, bool) {
+	te := ydb.TransportError(err)
+	if te == nil {
+		return Class{}, false
+	}
+	return transportClass(te), true
+}
+
+// transportClass classifies transport error, codes of transport errors are gRPC codes
+func transportClass(te ydb.Error) Class {
+	return Class{
+		Error:    "transport/" + te.Name(),
+		Code:     fmt.Sprintf("%06d", te.Code()),
+		Side:     SideServer,
+		GRPCCode: codes.Code(te.Code()).String(),
+	}
+}
+
+func operation(err error) (Class, bool) {
+	oe := ydb.OperationError(err)
+	if oe == nil {
+		return Class{}, false
+	}
+	return Class{
+		Error: "operation/" + oe.Name(),
+		Code:  fmt.Sprintf("%06d", oe.Code()),
+		Side:  SideServer,
+	}, true
+}
+
+// grpcStatus classifies bare gRPC status errors which SDK not wraps into transport errors
+func grpcStatus(err error) (Class, bool) {
+	s, ok := statusOf(err)
+	if !ok {
+		return Class{}, false
+	}
+	category := grpcCategory(s.Message())
+	side := SideServer
+	switch category {
+	case "connection_refused", "connection_reset", "connection_closed", "deadline", "canceled", "dns", "tls":
+		side = SideClient
+	}
+	return Class{
+		Error:    "grpc/" + s.Code().String() + "/" + category,
+		Code:     fmt.Sprintf("%06d", s.Code()),
+		Side:     side,
+		GRPCCode: s.Code().String(),
+	}, true
+}
+
+func ydbError(err error) (Class, bool) {
+	var e ydb.Error
+	if !errors.As(err, &e) {
+		return Class{}, false
+	}
+	return Class{
+		Error: e.Name(),
+		Code:  strconv.Itoa(int(e.Code())),
+	}, true
+}
+
+func unknown(err error) (Class, bool) {
+	return Class{
+		Error: "unknown",
+		Code:  "-1",
+	}, true
+}
@@ -0,0 +1,153 @@
+package errclass
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestDefaults(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		err      error
+		error    string
+		deadline string
+	}{
+		{
+			name:     "deadline exceeded",
+			err:      context.DeadlineExceeded,
+			error:    "context/DeadlineExceeded",
+			deadline: "true",
+		},
+		{
+			name:     "wrapped deadline exceeded",
+			err:      fmt.Errorf("query: %w", context.DeadlineExceeded),
+			error:    "context/DeadlineExceeded",
+			deadline: "true",
+		},
+		{
+			name:     "canceled",
+			err:      context.Canceled,
+			error:    "context/Canceled",
+			deadline: "false",
+		},
+		{
+			name: "wrapped connection refused",
+			err: fmt.Errorf("dial: %w", &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+			}),
+			error:    "network/dial/connection_refused",
+			deadline: "false",
+		},
+		{
+			name: "network timeout",
+			err: &net.OpError{
+				Op:  "read",
+				Net: "tcp",
+				Err: os.ErrDeadlineExceeded,
+			},
+			error:    "network/read/timeout",
+			deadline: "true",
+		},
+		{
+			name: "tls record header",
+			err: &net.OpError{
+				Op:  "remote error",
+				Err: tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"},
+			},
+			error:    "network/remote error/tls_handshake",
+			deadline: "false",
+		},
+		{
+			name: "untyped tls",
+			err: &net.OpError{
+				Op:  "remote error",
+				Err: fmt.Errorf("tls: handshake failure"),
+			},
+			error:    "network/remote error/tls_handshake",
+			deadline: "false",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := first(Defaults, tt.err)
+			if !ok {
+				t.Fatalf("error %v not classified", tt.err)
+			}
+			if c.Error != tt.error {
+				t.Errorf("unexpected error label: %q, expected %q", c.Error, tt.error)
+			}
+			if c.Deadline != tt.deadline {
+				t.Errorf("unexpected deadline label: %q, expected %q", c.Deadline, tt.deadline)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	errCustom := errors.New("custom")
+	custom := func(err error) (Class, bool) {
+		if !errors.Is(err, errCustom) {
+			return Class{}, false
+		}
+		return Class{
+			Error: "app/custom",
+			Side:  SideServer,
+		}, true
+	}
+	c := Classify([]Classifier{custom}, fmt.Errorf("wrapped: %w", errCustom))
+	if c.Error != "app/custom" || c.Side != SideServer {
+		t.Errorf("custom classifier not applied: %+v", c)
+	}
+	if c.Code != "-1" || c.Deadline != "false" || c.GRPCCode != "none" || c.Retryable == "" {
+		t.Errorf("empty fields not completed: %+v", c)
+	}
+	c = Classify([]Classifier{custom}, context.DeadlineExceeded)
+	if c.Error != "context/DeadlineExceeded" || c.Deadline != "true" {
+		t.Errorf("defaults not applied after custom classifier: %+v", c)
+	}
+	c = Classify(nil, errors.New("something"))
+	if c.Error != "unknown" || c.Side != SideClient {
+		t.Errorf("unexpected class of unknown error: %+v", c)
+	}
+}
+
+func TestGRPCCategory(t *testing.T) {
+	for message, category := range map[string]string{
+		"":                                     "none",
+		"connection error: connection refused": "connection_refused",
+		"transport is closing":                 "connection_closed",
+		"context deadline exceeded":            "deadline",
+		"tls: bad certificate":                 "tls",
+		"grpc: received message larger than max (5 vs. 4)": "message_size",
+		"table not found": "other",
+	} {
+		if got := grpcCategory(message); got != category {
+			t.Errorf("unexpected category of %q: %q, expected %q", message, got, category)
+		}
+	}
+}
+
+type testTransportError struct{}
+
+func (testTransportError) Error() string { return "transport error" }
+func (testTransportError) Code() int32   { return int32(codes.Unavailable) }
+func (testTransportError) Name() string  { return "Unavailable" }
+
+func TestTransportClass(t *testing.T) {
+	c := complete(transportClass(testTransportError{}), testTransportError{})
+	if c.Error != "transport/Unavailable" || c.Side != SideServer {
+		t.Errorf("unexpected class: %+v", c)
+	}
+	if c.GRPCCode != codes.Unavailable.String() {
+		t.Errorf("unexpected gRPC code of transport error without status: %q", c.GRPCCode)
+	}
+}
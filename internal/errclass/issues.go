@@ -0,0 +1,66 @@
+package errclass
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+)
+
+// Issues extracts most relevant issue code from issue trees of operation errors
+type Issues struct {
+	depth   int
+	allowed map[uint32]struct{}
+}
+
+// NewIssues makes Issues which walks issue tree to depth levels
+// Issue codes which not in allowed list labels as "other", empty allowed list allows all codes
+func NewIssues(depth int, allowed ...uint32) *Issues {
+	i := &Issues{
+		depth: depth,
+	}
+	if len(allowed) > 0 {
+		i.allowed = make(map[uint32]struct{}, len(allowed))
+		for _, code := range allowed {
+			i.allowed[code] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Code returns most relevant issue code of err or "none" if err has no issues
+// Most relevant issue is the first deepest issue with non-zero code in limits of depth,
+// because nested issues describes cause of parent issue
+func (i *Issues) Code(err error) string {
+	var e interface {
+		Issues() []*Ydb_Issue.IssueMessage
+	}
+	if !errors.As(err, &e) {
+		return "none"
+	}
+	code, _ := i.deepest(e.Issues(), 1)
+	if code == 0 {
+		return "none"
+	}
+	if i.allowed != nil {
+		if _, ok := i.allowed[code]; !ok {
+			return "other"
+		}
+	}
+	return strconv.FormatUint(uint64(code), 10)
+}
+
+func (i *Issues) deepest(issues []*Ydb_Issue.IssueMessage, level int) (code uint32, codeLevel int) {
+	if level > i.depth {
+		return 0, 0
+	}
+	for _, issue := range issues {
+		if c, l := i.deepest(issue.GetIssues(), level+1); c != 0 && l > codeLevel {
+			code, codeLevel = c, l
+		}
+		if c := issue.GetIssueCode(); c != 0 && level > codeLevel {
+			code, codeLevel = c, level
+		}
+	}
+	return code, codeLevel
+}
@@ -0,0 +1,118 @@
+package errclass
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Kinds of network errors
+const (
+	NetworkConnectionRefused  = "connection_refused"
+	NetworkConnectionReset    = "connection_reset"
+	NetworkConnectionAborted  = "connection_aborted"
+	NetworkBrokenPipe         = "broken_pipe"
+	NetworkTimeout            = "timeout"
+	NetworkHostUnreachable    = "host_unreachable"
+	NetworkNetworkUnreachable = "network_unreachable"
+	NetworkAddressNotAvail    = "address_not_available"
+	NetworkDNSNotFound        = "dns_not_found"
+	NetworkDNSTimeout         = "dns_timeout"
+	NetworkDNS                = "dns_failure"
+	NetworkTLSHandshake       = "tls_handshake"
+	NetworkTLSCertificate     = "tls_certificate"
+	NetworkEOF                = "eof"
+	NetworkClosed             = "closed"
+	NetworkOther              = "other"
+)
+
+// network classifies network errors into stable taxonomy without addresses and OS-specific text
+func network(err error) (Class, bool) {
+	var (
+		opErr *net.OpError
+		op    string
+	)
+	if errors.As(err, &opErr) {
+		op = opErr.Op + "/"
+	}
+	kind, ok := networkKind(err)
+	if !ok && opErr == nil {
+		return Class{}, false
+	}
+	if kind == NetworkOther && opErr.Err != nil && strings.HasPrefix(opErr.Err.Error(), "tls: ") {
+		// crypto/tls returns most of handshake errors as untyped errors
+		kind = NetworkTLSHandshake
+	}
+	return Class{
+		Error:    "network/" + op + kind,
+		Code:     "-1",
+		Deadline: boolString(kind == NetworkTimeout || kind == NetworkDNSTimeout),
+		Side:     SideClient,
+	}, true
+}
+
+// networkKind returns kind of network error and reports whether err is known network error
+func networkKind(err error) (string, bool) {
+	var (
+		dnsErr     *net.DNSError
+		recordErr  tls.RecordHeaderError
+		certErr    x509.CertificateInvalidError
+		hostErr    x509.HostnameError
+		unknownErr x509.UnknownAuthorityError
+		opErr      *net.OpError
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		switch {
+		case dnsErr.IsNotFound:
+			return NetworkDNSNotFound, true
+		case dnsErr.IsTimeout:
+			return NetworkDNSTimeout, true
+		default:
+			return NetworkDNS, true
+		}
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return NetworkConnectionRefused, true
+	case errors.Is(err, syscall.ECONNRESET):
+		return NetworkConnectionReset, true
+	case errors.Is(err, syscall.ECONNABORTED):
+		return NetworkConnectionAborted, true
+	case errors.Is(err, syscall.EPIPE):
+		return NetworkBrokenPipe, true
+	case errors.Is(err, syscall.EHOSTUNREACH):
+		return NetworkHostUnreachable, true
+	case errors.Is(err, syscall.ENETUNREACH):
+		return NetworkNetworkUnreachable, true
+	case errors.Is(err, syscall.EADDRNOTAVAIL):
+		return NetworkAddressNotAvail, true
+	case errors.Is(err, syscall.ETIMEDOUT), errors.Is(err, os.ErrDeadlineExceeded):
+		return NetworkTimeout, true
+	case errors.As(err, &recordErr):
+		return NetworkTLSHandshake, true
+	case errors.As(err, &certErr), errors.As(err, &hostErr), errors.As(err, &unknownErr):
+		return NetworkTLSCertificate, true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		// EOF without network context classifies as io/EOF
+		return NetworkEOF, false
+	case errors.Is(err, net.ErrClosed):
+		return NetworkClosed, true
+	case errors.As(err, &opErr) && opErr.Timeout():
+		// context.DeadlineExceeded also implements net.Error, so only timeouts of network
+		// operations classifies as network timeouts
+		return NetworkTimeout, true
+	default:
+		return NetworkOther, false
+	}
+}
+
+func boolString(v bool) string {
+	if v {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,24 @@
+package exemplar
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+)
+
+// FromContext returns exemplar labels with trace ID of active OpenTelemetry span
+// If context has no valid span context - returns nil
+func FromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return nil
+	}
+	return map[string]string{
+		labels.TagTraceID: spanContext.TraceID().String(),
+	}
+}
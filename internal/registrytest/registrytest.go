@@ -0,0 +1,250 @@
+// Package registrytest provides registry.Config which records registered metrics for tests
+package registrytest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// Config is a registry.Config which records metrics by names prefixed with systems joined by "_"
+// Config is safe for concurrent use
+type Config struct {
+	system string
+	mu     *sync.Mutex
+	// metrics is shared between Config and its subsystems
+	metrics map[string]*Metric
+}
+
+// New makes Config with all details
+func New() *Config {
+	return &Config{
+		mu:      &sync.Mutex{},
+		metrics: make(map[string]*Metric),
+	}
+}
+
+// Metric returns registered metric by full name, such as "table_session/calls"
+func (c *Config) Metric(name string) (*Metric, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, has := c.metrics[name]
+	return m, has
+}
+
+// Names returns sorted full names of registered metrics
+func (c *Config) Names() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.metrics))
+	for name := range c.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Value returns value of metric by full name over series with labels kv, see Metric.Value
+func (c *Config) Value(name string, kv map[string]string) float64 {
+	m, has := c.Metric(name)
+	if !has {
+		return 0
+	}
+	return m.Value(kv)
+}
+
+func (c *Config) Details() trace.Details {
+	return trace.DetailsAll
+}
+
+func (c *Config) WithSystem(subsystem string) registry.Config {
+	system := subsystem
+	if c.system != "" {
+		system = c.system + "_" + subsystem
+	}
+	return &Config{
+		system:  system,
+		mu:      c.mu,
+		metrics: c.metrics,
+	}
+}
+
+func (c *Config) metric(opts registry.Opts, labelNames []string) *Metric {
+	name := opts.Name
+	if c.system != "" {
+		name = c.system + "/" + name
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, has := c.metrics[name]
+	if !has {
+		m = &Metric{
+			mu:         c.mu,
+			LabelNames: labelNames,
+			series:     make(map[string]*series),
+		}
+		c.metrics[name] = m
+	}
+	return m
+}
+
+func (c *Config) CounterVec(opts registry.Opts, labelNames ...string) registry.CounterVec {
+	return c.metric(opts, labelNames)
+}
+
+func (c *Config) UpDownCounterVec(opts registry.Opts, labelNames ...string) registry.UpDownCounterVec {
+	return (*upDownCounterVec)(c.metric(opts, labelNames))
+}
+
+func (c *Config) GaugeVec(opts registry.Opts, labelNames ...string) registry.GaugeVec {
+	return (*gaugeVec)(c.metric(opts, labelNames))
+}
+
+func (c *Config) TimerVec(opts registry.Opts, labelNames ...string) registry.TimerVec {
+	return (*timerVec)(c.metric(opts, labelNames))
+}
+
+func (c *Config) HistogramVec(opts registry.Opts, _ []float64, labelNames ...string) registry.HistogramVec {
+	return (*histogramVec)(c.metric(opts, labelNames))
+}
+
+// Metric records series of metric by labels
+type Metric struct {
+	mu *sync.Mutex
+
+	LabelNames []string
+
+	order  []*series
+	series map[string]*series
+}
+
+type series struct {
+	m      *Metric
+	labels map[string]string
+	// value is a total of counters and up-down counters, last value of gauges
+	// and number of records of timers and histograms
+	value float64
+}
+
+// Labels returns labels of series in order of first use
+func (m *Metric) Labels() []map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kvs := make([]map[string]string, 0, len(m.order))
+	for _, s := range m.order {
+		kvs = append(kvs, s.labels)
+	}
+	return kvs
+}
+
+// Value returns sum of values of series which has all labels of kv
+// Value of series is a total of counter, last value of gauge or number of records of timer and histogram
+func (m *Metric) Value(kv map[string]string) (total float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.order {
+		if matches(s.labels, kv) {
+			total += s.value
+		}
+	}
+	return total
+}
+
+func matches(labels, kv map[string]string) bool {
+	for k, v := range kv {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func key(kv map[string]string) string {
+	pairs := make([]string, 0, len(kv))
+	for k, v := range kv {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (m *Metric) with(kv map[string]string) *series {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := key(kv)
+	s, has := m.series[k]
+	if !has {
+		labels := make(map[string]string, len(kv))
+		for k, v := range kv {
+			labels[k] = v
+		}
+		s = &series{
+			m:      m,
+			labels: labels,
+		}
+		m.series[k] = s
+		m.order = append(m.order, s)
+	}
+	return s
+}
+
+func (m *Metric) With(kv map[string]string) registry.Counter {
+	return m.with(kv)
+}
+
+func (s *series) Inc() {
+	s.Add(1)
+}
+
+func (s *series) Add(delta float64) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.value += delta
+}
+
+func (s *series) Set(value float64) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.value = value
+}
+
+func (s *series) Record(float64) {
+	s.Add(1)
+}
+
+type upDownCounterVec Metric
+
+func (v *upDownCounterVec) With(kv map[string]string) registry.UpDownCounter {
+	return (*Metric)(v).with(kv)
+}
+
+type gaugeVec Metric
+
+func (v *gaugeVec) With(kv map[string]string) registry.Gauge {
+	return (*Metric)(v).with(kv)
+}
+
+type timerVec Metric
+
+func (v *timerVec) With(kv map[string]string) registry.Timer {
+	return timer{(*Metric)(v).with(kv)}
+}
+
+type timer struct {
+	s *series
+}
+
+func (t timer) Record(time.Duration) {
+	t.s.Add(1)
+}
+
+type histogramVec Metric
+
+func (v *histogramVec) With(kv map[string]string) registry.Histogram {
+	return (*Metric)(v).with(kv)
+}
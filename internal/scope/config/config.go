@@ -1,11 +1,21 @@
 package config
 
+import (
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/amplification"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/caller"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/errclass"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
 type ValueType uint8
 
 const (
 	ValueTypeNone = ValueType(iota)
 	ValueTypeGauge
 	ValueTypeHistogram
+	ValueTypeCounter
+	ValueTypeUpDownCounter
 )
 
 type Config interface {
@@ -14,63 +24,169 @@ type Config interface {
 	HasError() bool
 	ValueType() ValueType
 	ValueBuckets() []float64
+	ValueUnit() registry.Unit
+	ValueDescription() string
+	Description() string
+	Stability() registry.Stability
+	DoneTags() []string
+	HasStages() bool
+	ContextLabels() []string
+	Callers() *caller.Resolver
+	DroppedTags() []string
+	AllowedTags() []string
+	ConstLabels() map[string]string
+	SamplingRate() float64
+	Classifiers() []errclass.Classifier
+	Issues() *errclass.Issues
+	DebugSink() errclass.Sink
+	Amplification() *amplification.Tracker
+	Disabled() bool
 }
 
 type config struct {
-	withLatency  bool
-	withCalls    bool
-	withError    bool
-	withValue    ValueType
-	valueBuckets []float64
+	withLatency      bool
+	withCalls        bool
+	withError        bool
+	withValue        ValueType
+	valueBuckets     []float64
+	valueUnit        registry.Unit
+	valueDescription string
+	description      string
+	stability        registry.Stability
+	doneTags         []string
+	withStages       bool
+	contextLabels    []string
+	callers          *caller.Resolver
+	droppedTags      []string
+	allowedTags      []string
+	constLabels      map[string]string
+	samplingRate     float64
+	classifiers      []errclass.Classifier
+	issues           *errclass.Issues
+	debugSink        errclass.Sink
+	amplification    *amplification.Tracker
+	disabled         bool
+}
+
+func (c *config) AllowedTags() []string {
+	return c.allowedTags
+}
+
+func (c *config) ConstLabels() map[string]string {
+	return c.constLabels
+}
+
+func (c *config) SamplingRate() float64 {
+	return c.samplingRate
+}
+
+func (c *config) Classifiers() []errclass.Classifier {
+	return c.classifiers
+}
+
+func (c *config) Issues() *errclass.Issues {
+	return c.issues
+}
+
+func (c *config) DebugSink() errclass.Sink {
+	return c.debugSink
+}
+
+func (c *config) Amplification() *amplification.Tracker {
+	return c.amplification
+}
+
+func (c *config) DroppedTags() []string {
+	return c.droppedTags
+}
+
+func (c *config) Callers() *caller.Resolver {
+	return c.callers
+}
+
+func (c *config) ContextLabels() []string {
+	return c.contextLabels
+}
+
+func (c *config) HasStages() bool {
+	return c.withStages
+}
+
+func (c *config) DoneTags() []string {
+	return c.doneTags
+}
+
+func (c *config) ValueDescription() string {
+	return c.valueDescription
+}
+
+func (c *config) ValueUnit() registry.Unit {
+	return c.valueUnit
+}
+
+func (c *config) Description() string {
+	return c.description
+}
+
+func (c *config) Stability() registry.Stability {
+	return c.stability
 }
 
 func (c *config) ValueBuckets() []float64 {
 	return c.valueBuckets
 }
 
+// Disabled reports whether all metrics of scope disabled
+func (c *config) Disabled() bool {
+	return c.disabled
+}
+
 func (c *config) HasLatency() bool {
-	return c.withLatency
+	return c.withLatency && !c.disabled
 }
 
 func (c *config) HasCalls() bool {
-	return c.withCalls
+	return c.withCalls && !c.disabled
 }
 
 func (c *config) HasError() bool {
-	return c.withError
+	return c.withError && !c.disabled
 }
 
 func (c *config) ValueType() ValueType {
+	if c.disabled {
+		return ValueTypeNone
+	}
 	return c.withValue
 }
 
-type option func(o *config)
+type Option func(o *config)
 
-func WithoutLatency() option {
+func WithoutLatency() Option {
 	return func(o *config) {
 		o.withLatency = false
 	}
 }
 
-func WithoutCalls() option {
+func WithoutCalls() Option {
 	return func(o *config) {
 		o.withCalls = false
 	}
 }
 
-func WithoutError() option {
+func WithoutError() Option {
 	return func(o *config) {
 		o.withError = false
 	}
 }
 
-func WithValue(valueType ValueType) option {
+func WithValue(valueType ValueType) Option {
 	return func(o *config) {
 		o.withValue = valueType
 	}
 }
 
-func WithValueOnly(valueType ValueType) option {
+func WithValueOnly(valueType ValueType) Option {
 	return func(o *config) {
 		o.withValue = valueType
 		o.withCalls = false
@@ -79,22 +195,187 @@ func WithValueOnly(valueType ValueType) option {
 	}
 }
 
-func WithValueBuckets(buckets []float64) option {
+func WithValueBuckets(buckets []float64) Option {
 	return func(o *config) {
 		o.valueBuckets = buckets
 	}
 }
 
-func New(opts ...option) Config {
+func WithValueUnit(unit registry.Unit) Option {
+	return func(o *config) {
+		o.valueUnit = unit
+	}
+}
+
+func WithValueDescription(description string) Option {
+	return func(o *config) {
+		o.valueDescription = description
+	}
+}
+
+// WithDescription sets human-readable description of scope
+// Description used as prefix of help text of all scope metrics
+func WithDescription(description string) Option {
+	return func(o *config) {
+		o.description = description
+	}
+}
+
+func WithStability(stability registry.Stability) Option {
+	return func(o *config) {
+		o.stability = stability
+	}
+}
+
+// WithDoneTags marks tags which values are known only on done of call
+// Such tags are excluded from started calls and in-flight calls metrics
+func WithDoneTags(tags ...string) Option {
+	return func(o *config) {
+		o.doneTags = append(o.doneTags, tags...)
+	}
+}
+
+// WithStages marks scope as multi-stage with stage tag
+// Multi-stage scope measures latency since previous stage, number of intermediate stages
+// and total duration of call instead of latency since start on each stage
+func WithStages() Option {
+	return func(o *config) {
+		o.withStages = true
+		o.doneTags = append(o.doneTags, labels.TagStage)
+	}
+}
+
+// WithContextLabels allows labels attached to context of call as scope tags
+// Labels which not attached to context of call has empty values
+func WithContextLabels(keys ...string) Option {
+	return func(o *config) {
+		o.contextLabels = append(o.contextLabels, keys...)
+	}
+}
+
+// WithCallers enables caller tag with name of function which calls SDK
+func WithCallers(callers *caller.Resolver) Option {
+	return func(o *config) {
+		o.callers = callers
+	}
+}
+
+// WithoutTags drops tags from scope metrics
+func WithoutTags(tags ...string) Option {
+	return func(o *config) {
+		o.droppedTags = append(o.droppedTags, tags...)
+	}
+}
+
+// WithAllowedTags drops tags of scope which not in allowlist
+func WithAllowedTags(tags ...string) Option {
+	return func(o *config) {
+		o.allowedTags = append([]string{}, tags...)
+	}
+}
+
+// WithConstLabels appends constant labels to all scope metrics
+func WithConstLabels(constLabels map[string]string) Option {
+	return func(o *config) {
+		merged := make(map[string]string, len(o.constLabels)+len(constLabels))
+		for k, v := range o.constLabels {
+			merged[k] = v
+		}
+		for k, v := range constLabels {
+			merged[k] = v
+		}
+		o.constLabels = merged
+	}
+}
+
+// WithSamplingRate sets fraction of calls which latency is measured
+// Counters of calls and errors are not sampled
+func WithSamplingRate(rate float64) Option {
+	return func(o *config) {
+		o.samplingRate = rate
+	}
+}
+
+// WithClassifiers appends error classifiers which applies before builtin classifiers
+func WithClassifiers(classifiers ...errclass.Classifier) Option {
+	return func(o *config) {
+		o.classifiers = append(o.classifiers, classifiers...)
+	}
+}
+
+// WithIssueCodes enables issue code tag of errors with most relevant issue code of operation errors
+// depth limits levels of issue tree, issue codes which not in allowed list labels as "other"
+func WithIssueCodes(depth int, allowed ...uint32) Option {
+	return func(o *config) {
+		o.issues = errclass.NewIssues(depth, allowed...)
+	}
+}
+
+// WithDebugSink sets sink of classified errors with raw error messages, such as network errors
+// with addresses of peers, which not used as labels
+func WithDebugSink(sink errclass.Sink) Option {
+	return func(o *config) {
+		o.debugSink = sink
+	}
+}
+
+// WithAmplification sets tracker of retry amplification of retry operations
+func WithAmplification(tracker *amplification.Tracker) Option {
+	return func(o *config) {
+		o.amplification = tracker
+	}
+}
+
+// Disabled disables all metrics of scope
+func Disabled() Option {
+	return func(o *config) {
+		o.disabled = true
+	}
+}
+
+// Enabled enables scope disabled with Disabled
+func Enabled() Option {
+	return func(o *config) {
+		o.disabled = false
+	}
+}
+
+func New(opts ...Option) Config {
 	h := &config{
-		withLatency:  true,
-		withCalls:    true,
-		withError:    true,
-		withValue:    ValueTypeNone,
-		valueBuckets: make([]float64, 0),
+		withLatency:      true,
+		withCalls:        true,
+		withError:        true,
+		withValue:        ValueTypeNone,
+		valueBuckets:     make([]float64, 0),
+		valueDescription: "value",
+		samplingRate:     1,
 	}
 	for _, o := range opts {
 		o(h)
 	}
 	return h
 }
+
+// Unwrap returns internal options of public scope.Option values
+// Unwrap is set on init of public scope package, which wraps internal options as opaque values
+var Unwrap func(opts interface{}) []Option
+
+// With returns copy of config with applied options
+func With(c Config, opts ...Option) Config {
+	cc, ok := c.(*config)
+	if !ok || len(opts) == 0 {
+		return c
+	}
+	copied := *cc
+	copied.doneTags = append([]string(nil), cc.doneTags...)
+	copied.contextLabels = append([]string(nil), cc.contextLabels...)
+	copied.droppedTags = append([]string(nil), cc.droppedTags...)
+	copied.classifiers = append([]errclass.Classifier(nil), cc.classifiers...)
+	if cc.allowedTags != nil {
+		copied.allowedTags = append([]string{}, cc.allowedTags...)
+	}
+	for _, o := range opts {
+		o(&copied)
+	}
+	return &copied
+}
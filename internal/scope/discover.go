@@ -0,0 +1,86 @@
+package scope
+
+import (
+	"time"
+
+	sdk "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// Discover returns tags of scopes made by fn by full paths of scopes, such as "table.do"
+// fn gets registry.Config with all details and without metrics
+func Discover(fn func(c registry.Config)) map[string][]string {
+	tags := make(map[string][]string)
+	fn(&overridesConfig{
+		Config: nopConfig{},
+		overrides: func(string) []config.Option {
+			return nil
+		},
+		discovered: tags,
+	})
+	return tags
+}
+
+// nopConfig is a registry.Config with all details and without metrics
+type nopConfig struct{}
+
+func (nopConfig) Details() sdk.Details {
+	return sdk.DetailsAll
+}
+
+func (c nopConfig) WithSystem(string) registry.Config {
+	return c
+}
+
+func (nopConfig) CounterVec(registry.Opts, ...string) registry.CounterVec {
+	return nopCounterVec{}
+}
+
+func (nopConfig) UpDownCounterVec(registry.Opts, ...string) registry.UpDownCounterVec {
+	return nopUpDownCounterVec{}
+}
+
+func (nopConfig) GaugeVec(registry.Opts, ...string) registry.GaugeVec {
+	return nopGaugeVec{}
+}
+
+func (nopConfig) TimerVec(registry.Opts, ...string) registry.TimerVec {
+	return nopTimerVec{}
+}
+
+func (nopConfig) HistogramVec(registry.Opts, []float64, ...string) registry.HistogramVec {
+	return nopHistogramVec{}
+}
+
+type nopMetric struct{}
+
+func (nopMetric) Inc()           {}
+func (nopMetric) Add(float64)    {}
+func (nopMetric) Set(float64)    {}
+func (nopMetric) Record(float64) {}
+
+type nopTimer struct{}
+
+func (nopTimer) Record(time.Duration) {}
+
+type nopCounterVec struct{}
+
+func (nopCounterVec) With(map[string]string) registry.Counter { return nopMetric{} }
+
+type nopUpDownCounterVec struct{}
+
+func (nopUpDownCounterVec) With(map[string]string) registry.UpDownCounter { return nopMetric{} }
+
+type nopGaugeVec struct{}
+
+func (nopGaugeVec) With(map[string]string) registry.Gauge { return nopMetric{} }
+
+type nopTimerVec struct{}
+
+func (nopTimerVec) With(map[string]string) registry.Timer { return nopTimer{} }
+
+type nopHistogramVec struct{}
+
+func (nopHistogramVec) With(map[string]string) registry.Histogram { return nopMetric{} }
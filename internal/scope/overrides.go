@@ -0,0 +1,68 @@
+package scope
+
+import (
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// Overrides returns options of scope by full path of scope, such as "table.do"
+type Overrides func(path string) []config.Option
+
+// States returns runtime state of scope by full path of scope
+// disabled reports whether scope disabled by config and can't be enabled at runtime
+// Nil state means scope is always enabled or always disabled by config
+type States func(path string, disabled bool) *State
+
+type overridesConfig struct {
+	registry.Config
+
+	systems   []string
+	overrides Overrides
+	states    States
+
+	// discovered collects tags of made scopes by full paths, see Discover
+	discovered map[string][]string
+}
+
+// WithOverrides returns registry.Config which tracks path of subsystems
+// and applies overrides to scopes created with New
+// states may be nil
+func WithOverrides(c registry.Config, overrides Overrides, states States) registry.Config {
+	return &overridesConfig{
+		Config:    c,
+		overrides: overrides,
+		states:    states,
+	}
+}
+
+// Unwrap returns registry.Config, overrides and states wrapped with WithOverrides
+// Unwrap returns c with nil overrides and states if c is not made with WithOverrides
+func Unwrap(c registry.Config) (registry.Config, Overrides, States) {
+	if o, ok := c.(*overridesConfig); ok && len(o.systems) == 0 {
+		return o.Config, o.overrides, o.states
+	}
+	return c, nil, nil
+}
+
+func (c *overridesConfig) WithSystem(subsystem string) registry.Config {
+	return &overridesConfig{
+		Config:     c.Config.WithSystem(subsystem),
+		systems:    append(append(make([]string, 0, len(c.systems)+1), c.systems...), subsystem),
+		overrides:  c.overrides,
+		states:     c.states,
+		discovered: c.discovered,
+	}
+}
+
+func (c *overridesConfig) path(name string) string {
+	return strings.Join(append(append(make([]string, 0, len(c.systems)+1), c.systems...), name), ".")
+}
+
+func (c *overridesConfig) state(path string, disabled bool) *State {
+	if c.states == nil {
+		return nil
+	}
+	return c.states(path, disabled)
+}
@@ -1,85 +1,316 @@
 package scope
 
 import (
+	"context"
+	"math/rand"
+	"sort"
 	"time"
 
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/ctxlabels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/errclass"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/exemplar"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/trace"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
 )
 
+// Scope makes traces of calls
+type Scope interface {
+	Start(lbls ...labels.Label) trace.Trace
+	StartWithContext(ctx context.Context, lbls ...labels.Label) trace.Trace
+
+	// Enabled reports whether scope measures calls
+	Enabled() bool
+}
+
 type callScope struct {
-	config  config.Config
-	latency registry.TimerVec
-	calls   registry.CounterVec
-	errs    registry.CounterVec
-	value   interface{} // TODO: go1.18: GaugeVec or HistogramVec
+	config   config.Config
+	state    *State
+	latency  registry.TimerVec
+	calls    registry.CounterVec
+	started  registry.CounterVec
+	inflight registry.UpDownCounterVec
+	errs     registry.CounterVec
+	value    interface{} // TODO: go1.18: GaugeVec, HistogramVec, CounterVec or UpDownCounterVec
+
+	stageLatency registry.TimerVec
+	stages       registry.HistogramVec
+	duration     registry.TimerVec
 }
 
-func (s *callScope) RecordValue(tags map[string]string, value float64) {
+var (
+	stagesBuckets = []float64{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 20, 50, 100, 200,
+	}
+)
+
+func (s *callScope) RecordValue(tags map[string]string, value float64, exemplar map[string]string) {
+	tags = s.normalize(tags)
 	switch s.config.ValueType() {
 	case config.ValueTypeGauge:
 		s.value.(registry.GaugeVec).With(tags).Set(value)
 	case config.ValueTypeHistogram:
-		s.value.(registry.HistogramVec).With(tags).Record(value)
+		h := s.value.(registry.HistogramVec).With(tags)
+		if e, ok := h.(registry.ExemplarHistogram); ok && exemplar != nil {
+			e.RecordWithExemplar(value, exemplar)
+		} else {
+			h.Record(value)
+		}
+	case config.ValueTypeCounter:
+		s.value.(registry.CounterVec).With(tags).Add(value)
+	case config.ValueTypeUpDownCounter:
+		s.value.(registry.UpDownCounterVec).With(tags).Add(value)
 	default:
 		// nop
 	}
 }
 
 func (s *callScope) Start(lbls ...labels.Label) trace.Trace {
+	return s.start(context.Background(), lbls...)
+}
+
+// StartWithContext starts trace and links its latency and value observations
+// with trace ID of OpenTelemetry span from ctx
+// Allowed labels attached to ctx with ctxlabels.With appends to all trace observations
+func (s *callScope) StartWithContext(ctx context.Context, lbls ...labels.Label) trace.Trace {
+	return s.start(ctx, lbls...)
+}
+
+// start counts started call and in-flight call by labels known at start of call
+// Start of disabled scope returns trace.Nop without allocations
+func (s *callScope) start(ctx context.Context, lbls ...labels.Label) trace.Trace {
+	if !s.Enabled() {
+		return trace.Nop
+	}
+	var (
+		e       = exemplar.FromContext(ctx)
+		common  = s.contextLabels(ctx)
+		sampled = s.sampled()
+	)
+	if !s.config.HasCalls() {
+		return trace.New(s, nil, e, sampled, common...)
+	}
+	tags := s.normalize(labels.KeyValue(append(append([]labels.Label{trace.Version}, lbls...), common...)...))
+	s.started.With(tags).Inc()
+	s.inflight.With(tags).Add(1)
+	return trace.New(s, tags, e, sampled, common...)
+}
+
+// Enabled reports whether scope measures calls
+// Trace hooks checks Enabled before making of labels, so disabled scope costs no allocations
+func (s *callScope) Enabled() bool {
+	return !s.config.Disabled() && s.enabled()
+}
+
+func (s *callScope) enabled() bool {
+	return s.state == nil || s.state.Enabled()
+}
+
+func (s *callScope) sampled() bool {
+	rate := s.config.SamplingRate()
+	if s.state != nil {
+		rate = s.state.SamplingRate(rate)
+	}
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate) //nolint:gosec
+}
+
+// contextLabels returns labels of call which known at start of call and
+// not provided by trace callbacks: allowed context labels and caller
+func (s *callScope) contextLabels(ctx context.Context) []labels.Label {
+	var (
+		keys    = s.config.ContextLabels()
+		callers = s.config.Callers()
+	)
+	if len(keys) == 0 && callers == nil {
+		return nil
+	}
+	values := ctxlabels.From(ctx)
+	lbls := make([]labels.Label, 0, len(keys)+1)
+	for _, key := range keys {
+		lbls = append(lbls, labels.Label{
+			Tag:   key,
+			Value: values[key],
+		})
+	}
+	if callers != nil {
+		lbls = append(lbls, labels.Label{
+			Tag:   labels.TagCaller,
+			Value: callers.Caller(),
+		})
+	}
+	return lbls
+}
+
+func (s *callScope) RemoveInflight(tags map[string]string) {
 	if s.config.HasCalls() {
-		s.calls.With(labels.KeyValue(
-			append([]labels.Label{trace.Version, {
-				Tag:   labels.TagSuccess,
-				Value: "wip",
-			}}, lbls...)...,
-		)).Inc()
+		s.inflight.With(tags).Add(-1)
 	}
-	return trace.New(s)
 }
 
 func (s *callScope) AddCall(tags map[string]string) {
-	if s.config.HasCalls() {
+	if s.config.HasCalls() && s.enabled() {
 		if tags == nil {
 			tags = map[string]string{
 				labels.TagSuccess: "true",
 				labels.TagVersion: trace.Version.Value,
 			}
 		}
-		s.calls.With(tags).Inc()
+		s.calls.With(s.normalize(tags)).Inc()
 	}
 }
 
-func (s *callScope) AddError(tags map[string]string) {
+// AddError counts error with labels of error classified by classifiers of scope config
+func (s *callScope) AddError(err error, tags map[string]string) {
 	if s.config.HasError() {
-		s.errs.With(tags).Inc()
+		c := errclass.Classify(s.config.Classifiers(), err)
+		for _, l := range c.Labels() {
+			tags[l.Tag] = l.Value
+		}
+		if sink := s.config.DebugSink(); sink != nil {
+			sink(c, err)
+		}
+		if issues := s.config.Issues(); issues != nil {
+			tags[labels.TagIssueCode] = issues.Code(err)
+		}
+		s.errs.With(s.normalize(tags)).Inc()
 	}
 }
 
-func (s *callScope) RecordLatency(tags map[string]string, latency time.Duration) {
+func (s *callScope) RecordLatency(tags map[string]string, latency time.Duration, exemplar map[string]string) {
 	if s.config.HasLatency() {
-		s.latency.With(tags).Record(latency)
+		record(s.latency.With(s.normalize(tags)), latency, exemplar)
+	}
+}
+
+// ObserveAttempts accounts attempts of finished retry operation in retry amplification tracker
+func (s *callScope) ObserveAttempts(idempotent string, attempts int) {
+	if tracker := s.config.Amplification(); tracker != nil && s.enabled() {
+		tracker.Observe(idempotent, attempts)
+	}
+}
+
+func (s *callScope) HasStages() bool {
+	return s.config.HasStages()
+}
+
+func (s *callScope) RecordStageLatency(tags map[string]string, latency time.Duration, exemplar map[string]string) {
+	if s.config.HasLatency() {
+		record(s.stageLatency.With(s.normalize(tags)), latency, exemplar)
+	}
+}
+
+// RecordStages records number of intermediate stages of finished call
+// Stage tag is excluded from tags because stages counts over all call
+func (s *callScope) RecordStages(tags map[string]string, stages int) {
+	if s.config.HasLatency() {
+		delete(tags, labels.TagStage)
+		s.stages.With(s.normalize(tags)).Record(float64(stages))
+	}
+}
+
+// RecordDuration records total duration of finished call since start
+func (s *callScope) RecordDuration(tags map[string]string, duration time.Duration, exemplar map[string]string) {
+	if s.config.HasLatency() {
+		delete(tags, labels.TagStage)
+		record(s.duration.With(s.normalize(tags)), duration, exemplar)
+	}
+}
+
+// normalize drops tags which disabled by config and appends constant labels
+func (s *callScope) normalize(tags map[string]string) map[string]string {
+	for _, tag := range s.config.DroppedTags() {
+		delete(tags, tag)
+	}
+	for k, v := range s.config.ConstLabels() {
+		tags[k] = v
+	}
+	return tags
+}
+
+func record(t registry.Timer, d time.Duration, exemplar map[string]string) {
+	if e, ok := t.(registry.ExemplarTimer); ok && exemplar != nil {
+		e.RecordWithExemplar(d, exemplar)
+	} else {
+		t.Record(d)
 	}
 }
 
 func New(c registry.Config, name string, cfg config.Config, tags ...string) *callScope {
+	var state *State
+	if o, ok := c.(*overridesConfig); ok {
+		path := o.path(name)
+		cfg = config.With(cfg, o.overrides(path)...)
+		state = o.state(path, cfg.Disabled())
+		if o.discovered != nil {
+			o.discovered[path] = append([]string(nil), tags...)
+		}
+	}
 	c = c.WithSystem(name)
+	tags = append(append(make([]string, 0, len(tags)+len(cfg.ContextLabels())+1), tags...), cfg.ContextLabels()...)
+	if cfg.Callers() != nil {
+		tags = append(tags, labels.TagCaller)
+	}
+	if allowed := cfg.AllowedTags(); allowed != nil {
+		cfg = config.With(cfg, config.WithoutTags(without(tags, allowed)...))
+	}
+	tags = without(tags, cfg.DroppedTags())
+	for _, k := range sortedKeys(cfg.ConstLabels()) {
+		tags = append(tags, k)
+	}
 	s := &callScope{
 		config: cfg,
+		state:  state,
 	}
 
 	if cfg.HasCalls() {
-		s.calls = c.CounterVec("calls", append([]string{labels.TagSuccess, labels.TagVersion}, tags...)...)
+		s.calls = c.CounterVec(opts(cfg, "calls", "number of calls", registry.UnitNone),
+			append([]string{labels.TagSuccess, labels.TagVersion}, tags...)...,
+		)
+		startTags := append([]string{labels.TagVersion}, without(tags, cfg.DoneTags())...)
+		s.started = c.CounterVec(opts(cfg, "started", "number of started calls", registry.UnitNone),
+			startTags...,
+		)
+		s.inflight = c.UpDownCounterVec(opts(cfg, "inflight", "number of in-flight calls", registry.UnitNone),
+			startTags...,
+		)
 	}
 
 	if cfg.HasLatency() {
-		s.latency = c.TimerVec("latency", append([]string{labels.TagSuccess, labels.TagVersion}, tags...)...)
+		s.latency = c.TimerVec(opts(cfg, "latency", "latency of calls", registry.UnitSeconds),
+			append([]string{labels.TagSuccess, labels.TagVersion}, tags...)...,
+		)
+	}
+
+	if cfg.HasLatency() && cfg.HasStages() {
+		s.stageLatency = c.TimerVec(opts(cfg, "stage_latency", "latency since previous stage", registry.UnitSeconds),
+			append([]string{labels.TagSuccess, labels.TagVersion}, tags...)...,
+		)
+		s.stages = c.HistogramVec(opts(cfg, "stages", "number of intermediate stages per call", registry.UnitNone),
+			stagesBuckets,
+			append([]string{labels.TagSuccess, labels.TagVersion}, without(tags, []string{labels.TagStage})...)...,
+		)
+		s.duration = c.TimerVec(opts(cfg, "duration", "total duration of calls", registry.UnitSeconds),
+			append([]string{labels.TagSuccess, labels.TagVersion}, without(tags, []string{labels.TagStage})...)...,
+		)
 	}
 
 	if cfg.HasError() {
-		s.errs = c.CounterVec("errors", append([]string{labels.TagVersion, labels.TagError, labels.TagErrCode}, tags...)...)
+		errTags := []string{
+			labels.TagVersion,
+			labels.TagError,
+			labels.TagErrCode,
+			labels.TagRetryable,
+			labels.TagDeadline,
+			labels.TagSide,
+			labels.TagGRPCCode,
+		}
+		if cfg.Issues() != nil {
+			errTags = append(errTags, labels.TagIssueCode)
+		}
+		s.errs = c.CounterVec(opts(cfg, "errors", "number of errors", registry.UnitNone),
+			without(append(errTags, tags...), cfg.DroppedTags())...,
+		)
 	}
 
 	if cfg.ValueType() == config.ValueTypeNone {
@@ -92,13 +323,57 @@ func New(c registry.Config, name string, cfg config.Config, tags ...string) *cal
 		tags = append(tags, labels.TagSuccess)
 	}
 
+	value := opts(cfg, "value", cfg.ValueDescription(), cfg.ValueUnit())
+
 	switch cfg.ValueType() {
 	case config.ValueTypeGauge:
-		s.value = c.GaugeVec("value", tags...)
+		s.value = c.GaugeVec(value, tags...)
 	case config.ValueTypeHistogram:
-		s.value = c.HistogramVec("value", cfg.ValueBuckets(), tags...)
+		s.value = c.HistogramVec(value, cfg.ValueBuckets(), tags...)
+	case config.ValueTypeCounter:
+		s.value = c.CounterVec(value, tags...)
+	case config.ValueTypeUpDownCounter:
+		s.value = c.UpDownCounterVec(value, tags...)
 	default:
 		// nop
 	}
 	return s
 }
+
+func opts(cfg config.Config, name, help string, unit registry.Unit) registry.Opts {
+	if cfg.Description() != "" {
+		help = cfg.Description() + ": " + help
+	}
+	return registry.Opts{
+		Name:      name,
+		Help:      help,
+		Unit:      unit,
+		Stability: cfg.Stability(),
+	}
+}
+
+func without(tags []string, exclude []string) []string {
+	filtered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		excluded := false
+		for _, e := range exclude {
+			if tag == e {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package scope
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// State is a runtime state of scope which may be changed concurrently with calls of scope
+// Zero value of State is an enabled scope with sampling rate of scope config
+type State struct {
+	disabled     uint32
+	overridden   uint32
+	samplingRate uint64
+}
+
+// NewState makes State of scope
+func NewState(enabled bool) *State {
+	s := &State{}
+	s.SetEnabled(enabled)
+	return s
+}
+
+// Enabled reports whether scope measures calls
+func (s *State) Enabled() bool {
+	return atomic.LoadUint32(&s.disabled) == 0
+}
+
+// SetEnabled enables or disables scope
+func (s *State) SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreUint32(&s.disabled, 0)
+	} else {
+		atomic.StoreUint32(&s.disabled, 1)
+	}
+}
+
+// SamplingRate returns overridden sampling rate or rate of scope config if sampling rate not overridden
+func (s *State) SamplingRate(rate float64) float64 {
+	if override, ok := s.SamplingRateOverride(); ok {
+		return override
+	}
+	return rate
+}
+
+// SamplingRateOverride returns overridden sampling rate and reports whether sampling rate overridden
+func (s *State) SamplingRateOverride() (rate float64, ok bool) {
+	if atomic.LoadUint32(&s.overridden) == 0 {
+		return 0, false
+	}
+	return math.Float64frombits(atomic.LoadUint64(&s.samplingRate)), true
+}
+
+// SetSamplingRate overrides sampling rate of scope config
+func (s *State) SetSamplingRate(rate float64) {
+	atomic.StoreUint64(&s.samplingRate, math.Float64bits(rate))
+	atomic.StoreUint32(&s.overridden, 1)
+}
+
+// ResetSamplingRate restores sampling rate of scope config
+func (s *State) ResetSamplingRate() {
+	atomic.StoreUint32(&s.overridden, 0)
+}
@@ -21,61 +21,152 @@ var (
 )
 
 type Trace interface {
+	// Sync finishes call with error and labels known on done of call
 	Sync(e error, lbls ...labels.Label)
 	SyncValue(v float64, lbls ...labels.Label)
 	SyncWithValue(err error, v float64, lbls ...labels.Label)
+
+	// Intermediate syncs intermediate stage of multi-stage call
+	// Call stays in-flight until one of Sync methods
+	Intermediate(err error, lbls ...labels.Label)
 }
 
 type Scope interface {
-	Start(lbls ...labels.Label) Trace
 	AddCall(tags map[string]string)
-	AddError(tags map[string]string)
-	RecordLatency(tags map[string]string, latency time.Duration)
-	RecordValue(tags map[string]string, value float64)
+	AddError(err error, tags map[string]string)
+	RemoveInflight(tags map[string]string)
+	RecordLatency(tags map[string]string, latency time.Duration, exemplar map[string]string)
+	RecordValue(tags map[string]string, value float64, exemplar map[string]string)
+
+	// HasStages reports whether scope measures stages of multi-stage calls
+	HasStages() bool
+	RecordStageLatency(tags map[string]string, latency time.Duration, exemplar map[string]string)
+	RecordStages(tags map[string]string, stages int)
+	RecordDuration(tags map[string]string, duration time.Duration, exemplar map[string]string)
 }
 
+// Nop is a Trace of disabled scope
+var Nop Trace = nop{}
+
+type nop struct{}
+
+func (nop) Sync(error, ...labels.Label)                   {}
+func (nop) SyncValue(float64, ...labels.Label)            {}
+func (nop) SyncWithValue(error, float64, ...labels.Label) {}
+func (nop) Intermediate(error, ...labels.Label)           {}
+
 type callTrace struct {
-	scope Scope
-	start time.Time
+	scope     Scope
+	start     time.Time
+	stage     time.Time
+	stages    int
+	startTags map[string]string
+	exemplar  map[string]string
+	common    []labels.Label
+	sampled   bool
+	done      bool
 }
 
-func New(s Scope) Trace {
+// New makes Trace which links latency and value observations with exemplar labels
+// startTags used for decrement in-flight calls on finish of call and may be nil
+// exemplar may be nil
+// sampled reports whether latency of call must be measured
+// common labels appends to labels of all trace observations
+func New(
+	s Scope,
+	startTags map[string]string,
+	exemplar map[string]string,
+	sampled bool,
+	common ...labels.Label,
+) Trace {
+	start := time.Now()
 	return &callTrace{
-		scope: s,
-		start: time.Now(),
+		scope:     s,
+		start:     start,
+		stage:     start,
+		startTags: startTags,
+		exemplar:  exemplar,
+		common:    common,
+		sampled:   sampled,
+	}
+}
+
+func (t *callTrace) withCommon(lbls []labels.Label) []labels.Label {
+	if len(t.common) == 0 {
+		return lbls
+	}
+	return append(append(make([]labels.Label, 0, len(lbls)+len(t.common)), lbls...), t.common...)
+}
+
+func (t *callTrace) finish() {
+	if t.done {
+		return
+	}
+	t.done = true
+	if t.startTags != nil {
+		t.scope.RemoveInflight(t.startTags)
 	}
 }
 
 func (t *callTrace) syncValue(v float64, lbls ...labels.Label) {
-	t.scope.RecordValue(labels.KeyValue(append([]labels.Label{Version}, lbls...)...), v)
+	t.scope.RecordValue(labels.KeyValue(append([]labels.Label{Version}, lbls...)...), v, t.exemplar)
 }
 
 func (t *callTrace) SyncValue(v float64, lbls ...labels.Label) {
+	lbls = t.withCommon(lbls)
+	t.finish()
 	t.syncValue(v, lbls...)
 }
 
 func (t *callTrace) SyncWithValue(err error, v float64, lbls ...labels.Label) {
+	lbls = t.withCommon(lbls)
+	t.finish()
 	t.syncError(err, lbls...)
-	t.syncValue(v, t.syncWithSuccess(err == nil, lbls...)...)
+	t.syncValue(v, t.syncWithSuccess(err == nil, true, lbls...)...)
 }
 
-func (t *callTrace) syncWithSuccess(ok bool, lbls ...labels.Label) (callLabels []labels.Label) {
+func (t *callTrace) syncWithSuccess(ok bool, final bool, lbls ...labels.Label) (callLabels []labels.Label) {
 	success := labels.Label{
 		Tag:   labels.TagSuccess,
 		Value: str.If(ok, "true", "false"),
 	}
-	t.scope.AddCall(labels.KeyValue(append([]labels.Label{Version, success}, lbls...)...))
-	t.scope.RecordLatency(labels.KeyValue(append([]labels.Label{Version, success}, lbls...)...), time.Since(t.start))
-	return append([]labels.Label{Version, success}, lbls...)
+	callLabels = append([]labels.Label{Version, success}, lbls...)
+	t.scope.AddCall(labels.KeyValue(callLabels...))
+	if !t.sampled {
+		return callLabels
+	}
+	now := time.Now()
+	if !t.scope.HasStages() {
+		t.scope.RecordLatency(labels.KeyValue(callLabels...), now.Sub(t.start), t.exemplar)
+		return callLabels
+	}
+	t.scope.RecordStageLatency(labels.KeyValue(callLabels...), now.Sub(t.stage), t.exemplar)
+	t.stage = now
+	if final {
+		t.scope.RecordLatency(labels.KeyValue(callLabels...), now.Sub(t.start), t.exemplar)
+		t.scope.RecordStages(labels.KeyValue(callLabels...), t.stages)
+		t.scope.RecordDuration(labels.KeyValue(callLabels...), now.Sub(t.start), t.exemplar)
+	} else {
+		t.stages++
+	}
+	return callLabels
 }
 
 func (t *callTrace) syncError(err error, lbls ...labels.Label) {
 	if err != nil {
-		t.scope.AddError(labels.KeyValue(labels.Err(err, append([]labels.Label{Version}, lbls...)...)...))
+		t.scope.AddError(err, labels.KeyValue(append([]labels.Label{Version}, lbls...)...))
 	}
 }
 
 func (t *callTrace) Sync(err error, lbls ...labels.Label) {
+	lbls = t.withCommon(lbls)
+	t.finish()
+	t.syncError(err, lbls...)
+	t.syncWithSuccess(err == nil, true, lbls...)
+}
+
+func (t *callTrace) Intermediate(err error, lbls ...labels.Label) {
+	lbls = t.withCommon(lbls)
 	t.syncError(err, lbls...)
-	t.syncWithSuccess(err == nil, lbls...)
+	t.syncWithSuccess(err == nil, false, lbls...)
 }
@@ -0,0 +1,234 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/amplification"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/caller"
+	internal "github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/scope"
+)
+
+// Option customizes metrics traces
+type Option func(o *options)
+
+type options struct {
+	details *trace.Details
+	scopes  map[string][]config.Option
+	runtime *Runtime
+	budget  *retryBudget
+
+	// parent returns options of scope from options of already wrapped registry.Config, such as FromEnv
+	parent internal.Overrides
+}
+
+type retryBudget struct {
+	budget  float64
+	windows []time.Duration
+
+	once    sync.Once
+	tracker *amplification.Tracker
+}
+
+// amplification returns tracker of retry budget
+// Tracker makes once, so traces made with the same option shares tracker and its metrics
+func (b *retryBudget) amplification(c registry.Config) *amplification.Tracker {
+	b.once.Do(func() {
+		b.tracker = amplification.New(c.WithSystem("retry"), b.budget, b.windows...)
+	})
+	return b.tracker
+}
+
+// overrides returns options of scope by full path of scope
+// Options of parent paths applies before options of nested paths, so
+// options for "driver.conn.park" overrides options for "driver.conn"
+// Options of parent applies before own options
+func (o *options) overrides(path string) (opts []config.Option) {
+	if o.parent != nil {
+		opts = append(opts, o.parent(path)...)
+	}
+	prefixes := make([]string, 0, len(o.scopes))
+	for prefix := range o.scopes {
+		if prefix == "" || path == prefix || strings.HasPrefix(path, prefix+".") {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) < len(prefixes[j])
+	})
+	for _, prefix := range prefixes {
+		opts = append(opts, o.scopes[prefix]...)
+	}
+	return opts
+}
+
+func (o *options) add(path string, opts ...config.Option) {
+	o.scopes[path] = append(o.scopes[path], opts...)
+}
+
+// WithDetails overrides details of registry.Config for select subsystems of SDK
+// which will be measured
+func WithDetails(details trace.Details) Option {
+	return func(o *options) {
+		o.details = &details
+	}
+}
+
+// WithoutScopes disables scopes by paths, such as "driver.conn.park"
+// Path of subsystem, such as "driver.conn", disables all nested scopes
+func WithoutScopes(paths ...string) Option {
+	return func(o *options) {
+		for _, path := range paths {
+			o.add(path, config.Disabled())
+		}
+	}
+}
+
+// WithScope customizes scope by path, such as "table.do", or all nested scopes by path of
+// subsystem, such as "table.session"
+// Use scope.WithoutCalls, scope.WithoutError and scope.WithoutLatency for switch off metrics,
+// scope.WithValueBuckets for override value buckets and scope.WithoutTags for override label set
+func WithScope(path string, opts ...scope.Option) Option {
+	return withScope(path, config.Unwrap(opts)...)
+}
+
+// withScope customizes scope or nested scopes by path with internal options
+func withScope(path string, opts ...config.Option) Option {
+	return func(o *options) {
+		o.add(path, opts...)
+	}
+}
+
+// WithConstLabels appends constant labels to metrics of all scopes
+func WithConstLabels(constLabels map[string]string) Option {
+	return func(o *options) {
+		o.add("", config.WithConstLabels(constLabels))
+	}
+}
+
+// WithErrorClassifiers appends error classifiers of all scopes
+// Classifiers applies in order before builtin classifiers, first classifier which knows error
+// makes error labels
+func WithErrorClassifiers(classifiers ...scope.ErrorClassifier) Option {
+	return func(o *options) {
+		o.add("", config.WithClassifiers(classifiers...))
+	}
+}
+
+// WithDebugSink sets sink of classified errors of all scopes with raw error messages, such as
+// network errors with addresses of peers, which not used as labels
+func WithDebugSink(sink scope.ErrorSink) Option {
+	return func(o *options) {
+		o.add("", config.WithDebugSink(sink))
+	}
+}
+
+// WithIssueCodes enables issueCode label of errors counters of all scopes with most relevant
+// issue code of operation errors. depth limits levels of issue tree, issue codes which not in
+// allowed list labels as "other"
+func WithIssueCodes(depth int, allowed ...uint32) Option {
+	return func(o *options) {
+		o.add("", config.WithIssueCodes(depth, allowed...))
+	}
+}
+
+// WithContextLabels allows labels attached to context with WithLabels as labels of scope metrics
+// path is a full path of scope, such as "table.do" or "database.sql.conn.query"
+func WithContextLabels(path string, keys ...string) Option {
+	return func(o *options) {
+		o.add(path, config.WithContextLabels(keys...))
+	}
+}
+
+// WithCallerLabel enables caller label of table.Do and table.DoTx metrics with name of function
+// which calls table.Do or table.DoTx. Number of distinct caller names are limited, callers over
+// limit are labelled as "other"
+func WithCallerLabel(limit int) Option {
+	return func(o *options) {
+		callers := caller.New(limit)
+		for _, path := range []string{"table.do", "table.do_tx"} {
+			o.add(path, config.WithCallers(callers))
+		}
+	}
+}
+
+// WithRetryBudget enables retry amplification gauges: total attempts divided by logical operations of
+// table.Do, table.DoTx and retry.Retry over rolling windows per idempotency class, and budget_exhausted
+// counter of operations finished while amplification exceeds budget. Default windows are 1m, 5m and 15m
+// Metrics of retry budget registers only if details allows table or retry events
+func WithRetryBudget(budget float64, windows ...time.Duration) Option {
+	b := &retryBudget{
+		budget:  budget,
+		windows: windows,
+	}
+	return func(o *options) {
+		o.budget = b
+	}
+}
+
+type detailsConfig struct {
+	registry.Config
+
+	details trace.Details
+}
+
+func (c *detailsConfig) Details() trace.Details {
+	return c.details
+}
+
+func (c *detailsConfig) WithSystem(subsystem string) registry.Config {
+	return &detailsConfig{
+		Config:  c.Config.WithSystem(subsystem),
+		details: c.details,
+	}
+}
+
+// withOptions wraps c for applying options to scopes
+func withOptions(c registry.Config, opts ...Option) registry.Config {
+	if len(opts) == 0 {
+		return c
+	}
+	c, parent, states := internal.Unwrap(c)
+	o := &options{
+		scopes: make(map[string][]config.Option),
+		parent: parent,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	if o.details != nil {
+		c = &detailsConfig{
+			Config:  c,
+			details: *o.details,
+		}
+	}
+	if o.budget != nil && (o.runtime != nil || c.Details()&(trace.TableEvents|trace.RetryEvents) != 0) {
+		tracker := o.budget.amplification(c)
+		for _, path := range []string{"table.do", "table.do_tx", "retry"} {
+			o.add(path, config.WithAmplification(tracker))
+		}
+	}
+	if o.runtime == nil {
+		return internal.WithOverrides(c, o.overrides, states)
+	}
+	details := c.Details()
+	return internal.WithOverrides(
+		&detailsConfig{
+			Config:  c,
+			details: trace.DetailsAll,
+		},
+		o.overrides,
+		func(path string, disabled bool) *internal.State {
+			return o.runtime.register(path, details, disabled)
+		},
+	)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/caller"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/scope"
+)
+
+func TestCallerSkipsRootPackage(t *testing.T) {
+	r := caller.New(10)
+	hook := func() string {
+		return r.Caller()
+	}
+	// hook and test function are in root package, so first caller outside of library is a test runner
+	if name := hook(); name != "testing.tRunner" {
+		t.Errorf("unexpected caller: %q", name)
+	}
+}
+
+func TestWithScope(t *testing.T) {
+	o := &options{
+		scopes: make(map[string][]config.Option),
+	}
+	WithScope("table.do", scope.WithoutCalls(), scope.WithSamplingRate(0.5))(o)
+	do := config.With(config.New(), o.overrides("table.do")...)
+	if do.HasCalls() || !do.HasError() || do.SamplingRate() != 0.5 {
+		t.Errorf("unexpected config of table.do: calls %v, error %v, sampling %v",
+			do.HasCalls(), do.HasError(), do.SamplingRate(),
+		)
+	}
+	if session := config.With(config.New(), o.overrides("table.session")...); !session.HasCalls() {
+		t.Error("options of table.do must not apply to table.session")
+	}
+}
@@ -1,10 +1,169 @@
 package metrics
 
 import (
-	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+	"context"
+	"path"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/ratelimiter"
+	ratelimiterOptions "github.com/ydb-platform/ydb-go-sdk/v3/ratelimiter/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/bounded"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// resourcesLimit caps number of distinct resource label values, resources over limit labels as "other"
+const resourcesLimit = 100
+
+var (
+	amountBuckets = []float64{
+		1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000,
+	}
 )
 
+// Ratelimiter makes trace.Ratelimiter
+// trace.Ratelimiter of SDK has no hooks, use RatelimiterClient for measuring rate limiter client calls
 func Ratelimiter(c registry.Config) (t trace.Ratelimiter) {
 	return t
 }
+
+// RatelimiterClient wraps client for measuring rate limiter resources operations and acquiring of resources
+// Latency of acquire scope is a waiting time of acquiring resource, acquire value is an amount requested
+// Resource label of acquire scope is a full path of resource: path of coordination node joined with
+// resource path, so resources with the same path in different coordination nodes are not merged
+func RatelimiterClient(c registry.Config, client ratelimiter.Client, opts ...Option) ratelimiter.Client {
+	c = withOptions(c, opts...).WithSystem("ratelimiter")
+	if c.Details()&trace.RatelimiterEvents == 0 {
+		return client
+	}
+	resource := c.WithSystem("resource")
+	return &ratelimiterClient{
+		Client:   client,
+		create:   scope.New(resource, "create", config.New(config.WithDescription("creating rate limiter resource"))),
+		alter:    scope.New(resource, "alter", config.New(config.WithDescription("altering rate limiter resource"))),
+		drop:     scope.New(resource, "drop", config.New(config.WithDescription("dropping rate limiter resource"))),
+		describe: scope.New(resource, "describe", config.New(config.WithDescription("describing rate limiter resource"))),
+		list: scope.New(resource, "list", config.New(
+			config.WithDescription("listing rate limiter resources"),
+			config.WithValueDescription("number of resources"),
+			config.WithValue(config.ValueTypeGauge),
+		)),
+		acquire: scope.New(c, "acquire", config.New(
+			config.WithDescription("acquiring rate limiter resource"),
+			config.WithValueDescription("amount of resource requested"),
+			config.WithValue(config.ValueTypeHistogram),
+			config.WithValueBuckets(amountBuckets),
+			config.WithDoneTags(labels.TagOutcome),
+		), labels.TagResource, labels.TagOutcome),
+		resources: bounded.New(resourcesLimit),
+	}
+}
+
+type ratelimiterClient struct {
+	ratelimiter.Client
+
+	create   scope.Scope
+	alter    scope.Scope
+	drop     scope.Scope
+	describe scope.Scope
+	list     scope.Scope
+	acquire  scope.Scope
+
+	resources *bounded.Values
+}
+
+func (c *ratelimiterClient) CreateResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resource ratelimiter.Resource,
+) (err error) {
+	start := c.create.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.CreateResource(ctx, coordinationNodePath, resource)
+}
+
+func (c *ratelimiterClient) AlterResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resource ratelimiter.Resource,
+) (err error) {
+	start := c.alter.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.AlterResource(ctx, coordinationNodePath, resource)
+}
+
+func (c *ratelimiterClient) DropResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resourcePath string,
+) (err error) {
+	start := c.drop.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.DropResource(ctx, coordinationNodePath, resourcePath)
+}
+
+func (c *ratelimiterClient) ListResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resourcePath string,
+	recursive bool,
+) (resources []string, err error) {
+	start := c.list.StartWithContext(ctx)
+	defer func() {
+		start.SyncWithValue(err, float64(len(resources)))
+	}()
+	return c.Client.ListResource(ctx, coordinationNodePath, resourcePath, recursive)
+}
+
+func (c *ratelimiterClient) DescribeResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resourcePath string,
+) (_ *ratelimiter.Resource, err error) {
+	start := c.describe.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.DescribeResource(ctx, coordinationNodePath, resourcePath)
+}
+
+// AcquireResource measures acquiring of resource with outcome label:
+// "acquired", "throttled" if resource not acquired in time, or "error"
+func (c *ratelimiterClient) AcquireResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resourcePath string,
+	amount uint64,
+	opts ...ratelimiterOptions.AcquireOption,
+) (err error) {
+	resource := labels.Label{
+		Tag:   labels.TagResource,
+		Value: c.resources.Value(path.Join(coordinationNodePath, resourcePath)),
+	}
+	start := c.acquire.StartWithContext(ctx, resource)
+	defer func() {
+		outcome := labels.Label{
+			Tag:   labels.TagOutcome,
+			Value: "acquired",
+		}
+		switch {
+		case err == nil:
+		case ydb.IsRatelimiterAcquireError(err):
+			outcome.Value = "throttled"
+		default:
+			outcome.Value = "error"
+		}
+		start.SyncWithValue(err, float64(amount), resource, outcome)
+	}()
+	return c.Client.AcquireResource(ctx, coordinationNodePath, resourcePath, amount, opts...)
+}
@@ -3,9 +3,20 @@ package registry
 // Counter counts value
 type Counter interface {
 	Inc()
+	Add(delta float64)
 }
 
 // CounterVec returns Counter from CounterVec by labels
 type CounterVec interface {
 	With(map[string]string) Counter
 }
+
+// UpDownCounter tracks value which may go up and down, such as in-flight calls
+type UpDownCounter interface {
+	Add(delta float64)
+}
+
+// UpDownCounterVec returns UpDownCounter from UpDownCounterVec by labels
+type UpDownCounterVec interface {
+	With(map[string]string) UpDownCounter
+}
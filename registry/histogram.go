@@ -9,3 +9,9 @@ type HistogramVec interface {
 type Histogram interface {
 	Record(v float64)
 }
+
+// ExemplarHistogram is an optional interface of Histogram which links recorded value
+// with exemplar labels, such as trace ID of measured call
+type ExemplarHistogram interface {
+	RecordWithExemplar(v float64, exemplar map[string]string)
+}
@@ -0,0 +1,45 @@
+package registry
+
+// Unit describes unit of metric values
+type Unit string
+
+const (
+	UnitNone    = Unit("")
+	UnitSeconds = Unit("seconds")
+	UnitBytes   = Unit("bytes")
+)
+
+// Stability describes stability level of metric
+// Experimental metrics may be renamed or removed in next releases
+type Stability uint8
+
+const (
+	StabilityStable = Stability(iota)
+	StabilityExperimental
+)
+
+func (s Stability) String() string {
+	switch s {
+	case StabilityStable:
+		return "stable"
+	case StabilityExperimental:
+		return "experimental"
+	default:
+		return "unknown"
+	}
+}
+
+// Opts describes metric created by Registry
+type Opts struct {
+	// Name is a metric name in subsystem
+	Name string
+
+	// Help is a human-readable description of metric
+	Help string
+
+	// Unit is a unit of metric values
+	Unit Unit
+
+	// Stability is a stability level of metric
+	Stability Stability
+}
@@ -1,23 +1,28 @@
 package registry
 
 type Registry interface {
-	// CounterVec returns CounterVec by name, subsystem and labels
+	// CounterVec returns CounterVec by options, subsystem and labels
 	// If counter by args already created - return counter from cache
 	// If counter by args nothing - create and return newest counter
-	CounterVec(name string, labelNames ...string) CounterVec
+	CounterVec(opts Opts, labelNames ...string) CounterVec
 
-	// GaugeVec returns GaugeVec by name, subsystem and labels
+	// UpDownCounterVec returns UpDownCounterVec by options, subsystem and labels
+	// If up-down counter by args already created - return up-down counter from cache
+	// If up-down counter by args nothing - create and return newest up-down counter
+	UpDownCounterVec(opts Opts, labelNames ...string) UpDownCounterVec
+
+	// GaugeVec returns GaugeVec by options, subsystem and labels
 	// If gauge by args already created - return gauge from cache
 	// If gauge by args nothing - create and return newest gauge
-	GaugeVec(name string, labelNames ...string) GaugeVec
+	GaugeVec(opts Opts, labelNames ...string) GaugeVec
 
-	// TimerVec returns TimerVec by name, subsystem and labels
+	// TimerVec returns TimerVec by options, subsystem and labels
 	// If timer by args already created - return timer from cache
 	// If timer by args nothing - create and return newest timer
-	TimerVec(name string, labelNames ...string) TimerVec
+	TimerVec(opts Opts, labelNames ...string) TimerVec
 
-	// HistogramVec returns HistogramVec by name, subsystem and labels
+	// HistogramVec returns HistogramVec by options, subsystem and labels
 	// If histogram by args already created - return histogram from cache
 	// If histogram by args nothing - create and return newest histogram
-	HistogramVec(name string, buckets []float64, labelNames ...string) HistogramVec
+	HistogramVec(opts Opts, buckets []float64, labelNames ...string) HistogramVec
 }
@@ -0,0 +1,346 @@
+package registry
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rules describes names and label values which accepted by metrics backend
+type Rules struct {
+	// NameStart reports whether r allowed as first character of subsystem, metric and label names
+	NameStart func(r rune) bool
+
+	// NameChar reports whether r allowed in subsystem, metric and label names
+	NameChar func(r rune) bool
+
+	// ValueChar reports whether r allowed in label values
+	ValueChar func(r rune) bool
+
+	// MaxNameLength limits length of names in runes, zero means unlimited
+	MaxNameLength int
+
+	// MaxValueLength limits length of label values in runes, zero means unlimited
+	// Longer values truncates with hash suffix of full value
+	MaxValueLength int
+
+	// Replacement replaces disallowed characters
+	Replacement rune
+}
+
+var (
+	// PrometheusRules follows Prometheus data model: names matches [a-zA-Z_][a-zA-Z0-9_]*,
+	// label values without control characters, quotes and backslashes
+	PrometheusRules = Rules{
+		NameStart: func(r rune) bool {
+			return r == '_' || isASCIILetter(r)
+		},
+		NameChar: func(r rune) bool {
+			return r == '_' || isASCIILetter(r) || isASCIIDigit(r)
+		},
+		ValueChar: func(r rune) bool {
+			return unicode.IsPrint(r) && r != '"' && r != '\\'
+		},
+		MaxValueLength: 256,
+		Replacement:    '_',
+	}
+
+	// StatsdRules follows statsd line protocol with tags: names and label values without
+	// protocol separators ':', '|', '@', '#', ',' and whitespaces
+	StatsdRules = Rules{
+		NameStart: isASCIILetter,
+		NameChar: func(r rune) bool {
+			return r == '_' || r == '-' || r == '.' || isASCIILetter(r) || isASCIIDigit(r)
+		},
+		ValueChar: func(r rune) bool {
+			return r > ' ' && r < unicode.MaxASCII && !strings.ContainsRune(":|@#,", r)
+		},
+		MaxNameLength:  200,
+		MaxValueLength: 200,
+		Replacement:    '_',
+	}
+
+	// OTelRules follows OpenTelemetry instrument name syntax: names matches
+	// [a-zA-Z][a-zA-Z0-9_.\-/]{0,254}, label values without control characters
+	OTelRules = Rules{
+		NameStart: isASCIILetter,
+		NameChar: func(r rune) bool {
+			return strings.ContainsRune("_.-/", r) || isASCIILetter(r) || isASCIIDigit(r)
+		},
+		ValueChar:      unicode.IsPrint,
+		MaxNameLength:  255,
+		MaxValueLength: 256,
+		Replacement:    '_',
+	}
+)
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Sanitize wraps c for rewriting subsystem, metric and label names and label values by rules
+// Sanitize counts rewrites with sanitizer_rewrites counter of c with kind label:
+// "name" for names, "value" for label values with disallowed characters and "truncate"
+// for truncated label values
+func Sanitize(c Config, rules Rules) Config {
+	rewrites := c.CounterVec(Opts{
+		Name:      "sanitizer_rewrites",
+		Help:      "number of rewritten names and label values",
+		Stability: StabilityExperimental,
+	}, "kind")
+	return &sanitizedConfig{
+		Config: c,
+		s: &sanitizer{
+			rules:     rules,
+			names:     rewrites.With(map[string]string{"kind": "name"}),
+			values:    rewrites.With(map[string]string{"kind": "value"}),
+			truncates: rewrites.With(map[string]string{"kind": "truncate"}),
+		},
+	}
+}
+
+type sanitizer struct {
+	rules     Rules
+	names     Counter
+	values    Counter
+	truncates Counter
+}
+
+// name rewrites disallowed characters of name and truncates long name
+func (s *sanitizer) name(name string) string {
+	var (
+		b         strings.Builder
+		rewritten bool
+		n         int
+	)
+	b.Grow(len(name))
+	for _, r := range name {
+		if s.rules.MaxNameLength > 0 && n == s.rules.MaxNameLength {
+			rewritten = true
+			break
+		}
+		allowed := s.rules.NameChar
+		if n == 0 {
+			allowed = s.rules.NameStart
+		}
+		if allowed != nil && !allowed(r) {
+			r, rewritten = s.rules.Replacement, true
+		}
+		b.WriteRune(r)
+		n++
+	}
+	if !rewritten {
+		return name
+	}
+	s.names.Inc()
+	return b.String()
+}
+
+// value rewrites disallowed characters of label value and truncates long value with hash suffix
+func (s *sanitizer) value(value string) string {
+	if s.valid(value) {
+		return value
+	}
+	var (
+		b         strings.Builder
+		rewritten bool
+	)
+	b.Grow(len(value))
+	for _, r := range value {
+		if s.rules.ValueChar != nil && !s.rules.ValueChar(r) {
+			r, rewritten = s.rules.Replacement, true
+		}
+		b.WriteRune(r)
+	}
+	if rewritten {
+		s.values.Inc()
+	}
+	return s.truncate(b.String(), value)
+}
+
+// valid reports whether value needs no rewrites
+func (s *sanitizer) valid(value string) bool {
+	if s.rules.MaxValueLength > 0 && len(value) > s.rules.MaxValueLength &&
+		utf8.RuneCountInString(value) > s.rules.MaxValueLength {
+		return false
+	}
+	if s.rules.ValueChar == nil {
+		return true
+	}
+	for _, r := range value {
+		if !s.rules.ValueChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate truncates value with hash suffix of original value
+func (s *sanitizer) truncate(value, original string) string {
+	const suffixLength = 9 // separator and 8 hex digits of hash
+	if s.rules.MaxValueLength <= suffixLength || utf8.RuneCountInString(value) <= s.rules.MaxValueLength {
+		return value
+	}
+	s.truncates.Inc()
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(original))
+	runes := []rune(value)[:s.rules.MaxValueLength-suffixLength]
+	return fmt.Sprintf("%s~%08x", string(runes), h.Sum32())
+}
+
+// labelNames rewrites label names and returns renames of label names
+func (s *sanitizer) labelNames(labelNames []string) (names []string, renames map[string]string) {
+	names = make([]string, len(labelNames))
+	for i, name := range labelNames {
+		names[i] = s.name(name)
+		if names[i] != name {
+			if renames == nil {
+				renames = make(map[string]string)
+			}
+			renames[name] = names[i]
+		}
+	}
+	return names, renames
+}
+
+func (s *sanitizer) opts(opts Opts) Opts {
+	opts.Name = s.name(opts.Name)
+	return opts
+}
+
+// labels returns labels with sanitized names and values
+// labels not copies kv if kv needs no rewrites
+func (s *sanitizer) labels(kv map[string]string, renames map[string]string) map[string]string {
+	clean := renames == nil
+	for _, v := range kv {
+		if !clean {
+			break
+		}
+		clean = s.valid(v)
+	}
+	if clean {
+		return kv
+	}
+	sanitized := make(map[string]string, len(kv))
+	for k, v := range kv {
+		if renamed, has := renames[k]; has {
+			k = renamed
+		}
+		sanitized[k] = s.value(v)
+	}
+	return sanitized
+}
+
+type sanitizedConfig struct {
+	Config
+
+	s *sanitizer
+}
+
+func (c *sanitizedConfig) WithSystem(subsystem string) Config {
+	return &sanitizedConfig{
+		Config: c.Config.WithSystem(c.s.name(subsystem)),
+		s:      c.s,
+	}
+}
+
+func (c *sanitizedConfig) CounterVec(opts Opts, labelNames ...string) CounterVec {
+	names, renames := c.s.labelNames(labelNames)
+	return &sanitizedCounterVec{
+		vec:     c.Config.CounterVec(c.s.opts(opts), names...),
+		s:       c.s,
+		renames: renames,
+	}
+}
+
+func (c *sanitizedConfig) UpDownCounterVec(opts Opts, labelNames ...string) UpDownCounterVec {
+	names, renames := c.s.labelNames(labelNames)
+	return &sanitizedUpDownCounterVec{
+		vec:     c.Config.UpDownCounterVec(c.s.opts(opts), names...),
+		s:       c.s,
+		renames: renames,
+	}
+}
+
+func (c *sanitizedConfig) GaugeVec(opts Opts, labelNames ...string) GaugeVec {
+	names, renames := c.s.labelNames(labelNames)
+	return &sanitizedGaugeVec{
+		vec:     c.Config.GaugeVec(c.s.opts(opts), names...),
+		s:       c.s,
+		renames: renames,
+	}
+}
+
+func (c *sanitizedConfig) TimerVec(opts Opts, labelNames ...string) TimerVec {
+	names, renames := c.s.labelNames(labelNames)
+	return &sanitizedTimerVec{
+		vec:     c.Config.TimerVec(c.s.opts(opts), names...),
+		s:       c.s,
+		renames: renames,
+	}
+}
+
+func (c *sanitizedConfig) HistogramVec(opts Opts, buckets []float64, labelNames ...string) HistogramVec {
+	names, renames := c.s.labelNames(labelNames)
+	return &sanitizedHistogramVec{
+		vec:     c.Config.HistogramVec(c.s.opts(opts), buckets, names...),
+		s:       c.s,
+		renames: renames,
+	}
+}
+
+type sanitizedCounterVec struct {
+	vec     CounterVec
+	s       *sanitizer
+	renames map[string]string
+}
+
+func (v *sanitizedCounterVec) With(kv map[string]string) Counter {
+	return v.vec.With(v.s.labels(kv, v.renames))
+}
+
+type sanitizedUpDownCounterVec struct {
+	vec     UpDownCounterVec
+	s       *sanitizer
+	renames map[string]string
+}
+
+func (v *sanitizedUpDownCounterVec) With(kv map[string]string) UpDownCounter {
+	return v.vec.With(v.s.labels(kv, v.renames))
+}
+
+type sanitizedGaugeVec struct {
+	vec     GaugeVec
+	s       *sanitizer
+	renames map[string]string
+}
+
+func (v *sanitizedGaugeVec) With(kv map[string]string) Gauge {
+	return v.vec.With(v.s.labels(kv, v.renames))
+}
+
+type sanitizedTimerVec struct {
+	vec     TimerVec
+	s       *sanitizer
+	renames map[string]string
+}
+
+func (v *sanitizedTimerVec) With(kv map[string]string) Timer {
+	return v.vec.With(v.s.labels(kv, v.renames))
+}
+
+type sanitizedHistogramVec struct {
+	vec     HistogramVec
+	s       *sanitizer
+	renames map[string]string
+}
+
+func (v *sanitizedHistogramVec) With(kv map[string]string) Histogram {
+	return v.vec.With(v.s.labels(kv, v.renames))
+}
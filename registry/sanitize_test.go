@@ -0,0 +1,93 @@
+package registry_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/registrytest"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+func TestSanitizeNames(t *testing.T) {
+	r := registrytest.New()
+	c := registry.Sanitize(r, registry.PrometheusRules).WithSystem("table.session")
+	c.CounterVec(registry.Opts{Name: "calls-total"}, "node-id", "stage").With(map[string]string{
+		"node-id": "1",
+		"stage":   "finish",
+	}).Inc()
+	m, has := r.Metric("table_session/calls_total")
+	if !has {
+		t.Fatalf("sanitized metric not registered: %v", r.Names())
+	}
+	if got := strings.Join(m.LabelNames, ","); got != "node_id,stage" {
+		t.Errorf("unexpected label names: %s", got)
+	}
+	if got := m.Labels()[0]["node_id"]; got != "1" {
+		t.Errorf("label of renamed tag not rewritten: %v", m.Labels()[0])
+	}
+	// "table.session", "calls-total" and "node-id"
+	if got := rewrites(r, "name"); got != 3 {
+		t.Errorf("unexpected name rewrites: %v", got)
+	}
+}
+
+func TestSanitizeValues(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		rules    registry.Rules
+		value    string
+		expected string
+		kind     string
+	}{
+		{
+			name:     "valid",
+			rules:    registry.PrometheusRules,
+			value:    "table.do",
+			expected: "table.do",
+		},
+		{
+			name:     "prometheus quotes",
+			rules:    registry.PrometheusRules,
+			value:    `say "hi"`,
+			expected: "say _hi_",
+			kind:     "value",
+		},
+		{
+			name:     "statsd separators",
+			rules:    registry.StatsdRules,
+			value:    "host:2135|a",
+			expected: "host_2135_a",
+			kind:     "value",
+		},
+		{
+			name:     "truncate",
+			rules:    registry.Rules{MaxValueLength: 12},
+			value:    "0123456789abcdef",
+			expected: "012~87bc333d",
+			kind:     "truncate",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := registrytest.New()
+			registry.Sanitize(r, tt.rules).CounterVec(registry.Opts{Name: "calls"}, "value").With(map[string]string{
+				"value": tt.value,
+			}).Inc()
+			m, has := r.Metric("calls")
+			if !has {
+				t.Fatalf("metric not registered: %v", r.Names())
+			}
+			if got := m.Labels()[0]["value"]; got != tt.expected {
+				t.Errorf("unexpected value: %q, expected %q", got, tt.expected)
+			}
+			if tt.kind != "" && rewrites(r, tt.kind) != 1 {
+				t.Errorf("rewrite of kind %q not counted", tt.kind)
+			}
+		})
+	}
+}
+
+func rewrites(r *registrytest.Config, kind string) float64 {
+	return r.Value("sanitizer_rewrites", map[string]string{
+		"kind": kind,
+	})
+}
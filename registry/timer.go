@@ -11,3 +11,9 @@ type TimerVec interface {
 type Timer interface {
 	Record(d time.Duration)
 }
+
+// ExemplarTimer is an optional interface of Timer which links recorded value
+// with exemplar labels, such as trace ID of measured call
+type ExemplarTimer interface {
+	RecordWithExemplar(d time.Duration, exemplar map[string]string)
+}
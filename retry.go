@@ -6,20 +6,39 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/str"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
 )
 
-// Retry makes table.RetryTrace with New publishing
-func Retry(c registry.Config) (t trace.Retry) {
+// Retry makes trace.Retry with measuring retry loops
+// Stage latency of intermediate stage is a latency of failed attempt including backoff sleep,
+// because SDK traces attempt after backoff. Errors of intermediate stage with error class and
+// retryable labels are reasons of retries
+// Backoff sleep duration is not measured separately: trace.Retry has no hooks around backoff
+// and default backoffs of retry loop are internal to SDK, so it can't be timed by wrapper
+func Retry(c registry.Config, opts ...Option) (t trace.Retry) {
+	c = withOptions(c, opts...)
 	if c.Details()&trace.RetryEvents != 0 {
 		retry := scope.New(c, "retry",
 			config.New(
+				config.WithDescription("retry loop"),
+				config.WithValueDescription("number of attempts"),
+				config.WithStages(),
 				config.WithValue(config.ValueTypeHistogram),
 				config.WithValueBuckets([]float64{
 					1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 20, 50, 100, 200,
 				}),
 			),
-			labels.TagIdempotent, labels.TagStage, labels.TagID,
+			labels.TagIdempotent, labels.TagStage,
+		)
+		attempts := scope.New(c.WithSystem("retry"), "attempts",
+			config.New(
+				config.WithDescription("retry loop attempts"),
+				config.WithValueDescription("total number of attempts"),
+				config.WithStability(registry.StabilityExperimental),
+				config.WithValueOnly(config.ValueTypeCounter),
+			),
+			labels.TagIdempotent,
 		)
 		t.OnRetry = func(
 			info trace.RetryLoopStartInfo,
@@ -28,37 +47,30 @@ func Retry(c registry.Config) (t trace.Retry) {
 		) func(
 			trace.RetryLoopDoneInfo,
 		) {
-			idempotent := labels.Label{
-				Tag: labels.TagIdempotent,
-				Value: func() string {
-					if info.Idempotent {
-						return "true"
-					}
-					return "false"
-				}(),
+			if !retry.Enabled() && !attempts.Enabled() {
+				return nil
 			}
-			id := labels.Label{
-				Tag:   labels.TagID,
-				Value: info.ID,
+			idempotent := labels.Label{
+				Tag:   labels.TagIdempotent,
+				Value: str.If(info.Idempotent, "true", "false"),
 			}
-			start := retry.Start(idempotent, id, labels.Label{
-				Tag:   labels.TagStage,
-				Value: "init",
-			})
+			start := retry.StartWithContext(contextOf(info.Context), idempotent)
 			return func(
 				info trace.RetryLoopIntermediateInfo,
 			) func(
 				trace.RetryLoopDoneInfo,
 			) {
-				start.Sync(info.Error, idempotent, id, labels.Label{
+				start.Intermediate(info.Error, idempotent, labels.Label{
 					Tag:   labels.TagStage,
 					Value: "intermediate",
 				})
 				return func(info trace.RetryLoopDoneInfo) {
-					start.SyncWithValue(info.Error, float64(info.Attempts), idempotent, id, labels.Label{
+					start.SyncWithValue(info.Error, float64(info.Attempts), idempotent, labels.Label{
 						Tag:   labels.TagStage,
 						Value: "finish",
 					})
+					attempts.Start(idempotent).SyncValue(float64(info.Attempts), idempotent)
+					retry.ObserveAttempts(idempotent.Value, info.Attempts)
 				}
 			}
 		}
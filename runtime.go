@@ -0,0 +1,262 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	internal "github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+)
+
+// Runtime allows to enable and disable scopes and change sampling rates of scopes
+// after installing of trace hooks, without reconnecting of driver
+// Traces configured with WithRuntime installs hooks of all subsystems. Scopes of subsystems
+// which not allowed by details of registry.Config starts disabled and may be enabled with Enable
+// Scopes disabled by WithoutScopes or config file have no metrics and can't be enabled
+type Runtime struct {
+	mu       sync.RWMutex
+	states   map[string]*internal.State
+	disabled map[string]struct{}
+	rules    []runtimeRule
+}
+
+// ScopeState is a snapshot of runtime state of scope
+type ScopeState struct {
+	Path    string `json:"path"`
+	Enabled bool   `json:"enabled"`
+
+	// Fixed reports whether scope disabled by configuration and can't be enabled
+	Fixed bool `json:"fixed,omitempty"`
+
+	// SamplingRate is an overridden sampling rate or nil if scope uses sampling rate of config
+	SamplingRate *float64 `json:"sampling_rate,omitempty"`
+}
+
+type runtimeRule struct {
+	path         string
+	enabled      *bool
+	samplingRate *float64
+}
+
+func (r runtimeRule) matches(path string) bool {
+	return r.path == "" || path == r.path || strings.HasPrefix(path, r.path+".")
+}
+
+func (r runtimeRule) apply(s *internal.State) {
+	if r.enabled != nil {
+		s.SetEnabled(*r.enabled)
+	}
+	if r.samplingRate != nil {
+		s.SetSamplingRate(*r.samplingRate)
+	}
+}
+
+// NewRuntime makes Runtime for WithRuntime option
+func NewRuntime() *Runtime {
+	return &Runtime{
+		states:   make(map[string]*internal.State),
+		disabled: make(map[string]struct{}),
+	}
+}
+
+// WithRuntime makes traces reconfigurable with r
+func WithRuntime(r *Runtime) Option {
+	return func(o *options) {
+		o.runtime = r
+	}
+}
+
+// register returns state of scope by full path of scope
+// Scope enabled initially if details allows scope and runtime rules not disables it
+// Scope disabled by configuration registers as fixed and has no state
+func (r *Runtime) register(path string, details trace.Details, disabled bool) *internal.State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if disabled {
+		r.disabled[path] = struct{}{}
+		return nil
+	}
+	if s, has := r.states[path]; has {
+		return s
+	}
+	enabled := true
+	if d, has := scopes[path]; has {
+		enabled = details&d != 0
+	}
+	s := internal.NewState(enabled)
+	for _, rule := range r.rules {
+		if rule.matches(path) {
+			rule.apply(s)
+		}
+	}
+	r.states[path] = s
+	return s
+}
+
+// update applies rule to registered scopes and keeps rule for scopes registered later
+// Rule overrides fields of previous rules of the same or nested paths, so previous rules
+// keeps only fields which rule not sets and number of rules not grows on repeated updates
+func (r *Runtime) update(rule runtimeRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rules := r.rules[:0]
+	for _, prev := range r.rules {
+		if rule.matches(prev.path) {
+			if rule.enabled != nil {
+				prev.enabled = nil
+			}
+			if rule.samplingRate != nil {
+				prev.samplingRate = nil
+			}
+			if prev.enabled == nil && prev.samplingRate == nil {
+				continue
+			}
+		}
+		rules = append(rules, prev)
+	}
+	r.rules = append(rules, rule)
+	for path, s := range r.states {
+		if rule.matches(path) {
+			rule.apply(s)
+		}
+	}
+}
+
+// fixed returns sorted paths of scopes disabled by configuration which matches rule
+func (r *Runtime) fixed(rule runtimeRule) (paths []string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for path := range r.disabled {
+		if rule.matches(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Enable enables scopes by paths, such as "driver.conn.park"
+// Path of subsystem, such as "driver.conn", enables all nested scopes
+// Enable returns error with paths of matched scopes which disabled by configuration and
+// can't be enabled, other matched scopes are enabled
+func (r *Runtime) Enable(paths ...string) error {
+	var (
+		enabled = true
+		fixed   []string
+	)
+	for _, path := range paths {
+		rule := runtimeRule{path: path, enabled: &enabled}
+		r.update(rule)
+		fixed = append(fixed, r.fixed(rule)...)
+	}
+	if len(fixed) > 0 {
+		return fmt.Errorf("metrics: scopes disabled by configuration can't be enabled: %s", strings.Join(fixed, ", "))
+	}
+	return nil
+}
+
+// Disable disables scopes by paths, such as "driver.conn.park"
+// Path of subsystem, such as "driver.conn", disables all nested scopes
+func (r *Runtime) Disable(paths ...string) {
+	enabled := false
+	for _, path := range paths {
+		r.update(runtimeRule{path: path, enabled: &enabled})
+	}
+}
+
+// SetSamplingRate overrides sampling rate of latency for scopes by path
+// rate must be in range [0, 1]
+func (r *Runtime) SetSamplingRate(path string, rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("metrics: sampling rate must be in range [0, 1]: %v", rate)
+	}
+	r.update(runtimeRule{path: path, samplingRate: &rate})
+	return nil
+}
+
+// Scopes returns states of registered scopes sorted by path
+func (r *Runtime) Scopes() []ScopeState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	states := make([]ScopeState, 0, len(r.states)+len(r.disabled))
+	for path := range r.disabled {
+		states = append(states, ScopeState{
+			Path:  path,
+			Fixed: true,
+		})
+	}
+	for path, s := range r.states {
+		state := ScopeState{
+			Path:    path,
+			Enabled: s.Enabled(),
+		}
+		if rate, ok := s.SamplingRateOverride(); ok {
+			state.SamplingRate = &rate
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Path < states[j].Path
+	})
+	return states
+}
+
+// Handler returns admin HTTP handler of r
+// GET responds with JSON list of scope states
+// POST changes scopes selected by "path" parameter with "enabled" and "sampling_rate" parameters
+// and responds with JSON list of scope states
+func (r *Runtime) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			if err := r.handle(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r.Scopes())
+	})
+}
+
+func (r *Runtime) handle(req *http.Request) error {
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	path := req.Form.Get("path")
+	if path != "" && !knownScope(path) {
+		return fmt.Errorf("unknown scope path %q", path)
+	}
+	if v := req.Form.Get("enabled"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid enabled %q: %w", v, err)
+		}
+		if enabled {
+			if err := r.Enable(path); err != nil {
+				return err
+			}
+		} else {
+			r.Disable(path)
+		}
+	}
+	if v := req.Form.Get("sampling_rate"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid sampling_rate %q: %w", v, err)
+		}
+		return r.SetSamplingRate(path, rate)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestRuntimeRules(t *testing.T) {
+	r := NewRuntime()
+	for i := 0; i < 100; i++ {
+		_ = r.Enable("driver.conn.park")
+		r.Disable("driver.conn.park")
+		if err := r.SetSamplingRate("driver.conn.park", 0.5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.rules) != 2 {
+		t.Fatalf("unexpected number of rules after repeated updates: %d", len(r.rules))
+	}
+	park := r.register("driver.conn.park", trace.DetailsAll, false)
+	if park.Enabled() {
+		t.Error("driver.conn.park must be disabled by last rule")
+	}
+	if rate, ok := park.SamplingRateOverride(); !ok || rate != 0.5 {
+		t.Errorf("unexpected sampling rate: %v, %v", rate, ok)
+	}
+	_ = r.Enable("driver")
+	if len(r.rules) != 2 {
+		t.Fatalf("unexpected number of rules after update of parent path: %d", len(r.rules))
+	}
+	if !park.Enabled() {
+		t.Error("driver.conn.park must be enabled by rule of parent path")
+	}
+	if take := r.register("driver.conn.take", 0, false); !take.Enabled() {
+		t.Error("driver.conn.take must be enabled by rule of parent path")
+	}
+}
@@ -1,11 +1,100 @@
 package metrics
 
 import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/scheme"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
 	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
 )
 
+var (
+	entriesBuckets = []float64{
+		0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000,
+	}
+)
+
+// Scheme makes trace.Scheme
+// trace.Scheme of SDK has no hooks, use SchemeClient for measuring scheme client calls
 func Scheme(c registry.Config) (t trace.Scheme) {
 	return t
 }
+
+// SchemeClient wraps client for measuring calls, errors and latency of scheme operations
+func SchemeClient(c registry.Config, client scheme.Client, opts ...Option) scheme.Client {
+	c = withOptions(c, opts...)
+	if c.Details()&trace.SchemeEvents == 0 {
+		return client
+	}
+	c = c.WithSystem("scheme")
+	return &schemeClient{
+		Client: client,
+		listDirectory: scope.New(c, "list_directory", config.New(
+			config.WithDescription("listing directory"),
+			config.WithValueDescription("number of directory entries"),
+			config.WithValue(config.ValueTypeHistogram),
+			config.WithValueBuckets(entriesBuckets),
+		)),
+		describePath:      scope.New(c, "describe_path", config.New(config.WithDescription("describing path"))),
+		makeDirectory:     scope.New(c, "make_directory", config.New(config.WithDescription("making directory"))),
+		removeDirectory:   scope.New(c, "remove_directory", config.New(config.WithDescription("removing directory"))),
+		modifyPermissions: scope.New(c, "modify_permissions", config.New(config.WithDescription("modifying permissions"))),
+	}
+}
+
+type schemeClient struct {
+	scheme.Client
+
+	listDirectory     scope.Scope
+	describePath      scope.Scope
+	makeDirectory     scope.Scope
+	removeDirectory   scope.Scope
+	modifyPermissions scope.Scope
+}
+
+func (c *schemeClient) ListDirectory(ctx context.Context, path string) (d scheme.Directory, err error) {
+	start := c.listDirectory.StartWithContext(ctx)
+	defer func() {
+		start.SyncWithValue(err, float64(len(d.Children)))
+	}()
+	return c.Client.ListDirectory(ctx, path)
+}
+
+func (c *schemeClient) DescribePath(ctx context.Context, path string) (_ scheme.Entry, err error) {
+	start := c.describePath.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.DescribePath(ctx, path)
+}
+
+func (c *schemeClient) MakeDirectory(ctx context.Context, path string) (err error) {
+	start := c.makeDirectory.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.MakeDirectory(ctx, path)
+}
+
+func (c *schemeClient) RemoveDirectory(ctx context.Context, path string) (err error) {
+	start := c.removeDirectory.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.RemoveDirectory(ctx, path)
+}
+
+func (c *schemeClient) ModifyPermissions(
+	ctx context.Context,
+	path string,
+	opts ...scheme.PermissionsOption,
+) (err error) {
+	start := c.modifyPermissions.StartWithContext(ctx)
+	defer func() {
+		start.Sync(err)
+	}()
+	return c.Client.ModifyPermissions(ctx, path, opts...)
+}
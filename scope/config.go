@@ -0,0 +1,245 @@
+package scope
+
+import (
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/errclass"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// Config describes metrics of scope
+// Zero Config describes calls, errors and latency metrics, such as NewConfig without options
+type Config struct {
+	config config.Config
+}
+
+// Option customizes Config
+type Option struct {
+	option config.Option
+}
+
+func init() {
+	config.Unwrap = func(opts interface{}) []config.Option {
+		public := opts.([]Option)
+		options := make([]config.Option, 0, len(public))
+		for _, o := range public {
+			if o.option != nil {
+				options = append(options, o.option)
+			}
+		}
+		return options
+	}
+}
+
+// unwrap returns internal config of c
+func (c Config) unwrap() config.Config {
+	if c.config == nil {
+		return config.New()
+	}
+	return c.config
+}
+
+// ValueType describes type of value metric
+type ValueType = config.ValueType
+
+const (
+	ValueTypeNone          = config.ValueTypeNone
+	ValueTypeGauge         = config.ValueTypeGauge
+	ValueTypeHistogram     = config.ValueTypeHistogram
+	ValueTypeCounter       = config.ValueTypeCounter
+	ValueTypeUpDownCounter = config.ValueTypeUpDownCounter
+)
+
+// Tags used by SDK metrics
+const (
+	TagVersion    = labels.TagVersion
+	TagSuccess    = labels.TagSuccess
+	TagError      = labels.TagError
+	TagErrCode    = labels.TagErrCode
+	TagStage      = labels.TagStage
+	TagMethod     = labels.TagMethod
+	TagName       = labels.TagName
+	TagAddress    = labels.TagAddress
+	TagNodeID     = labels.TagNodeID
+	TagIdempotent = labels.TagIdempotent
+	TagRetryable  = labels.TagRetryable
+	TagDeadline   = labels.TagDeadline
+	TagSide       = labels.TagSide
+	TagGRPCCode   = labels.TagGRPCCode
+	TagIssueCode  = labels.TagIssueCode
+)
+
+// ErrorClass is a classification of error for error labels
+// Empty Retryable, Deadline and Side fields detects by SDK helpers
+type ErrorClass = errclass.Class
+
+// ErrorClassifier classifies error
+// ErrorClassifier returns false if error is not known and next classifier must be used
+type ErrorClassifier = errclass.Classifier
+
+// ErrorSink receives classified errors with raw error messages which not used as labels
+type ErrorSink = errclass.Sink
+
+// Values of ErrorClass fields
+const (
+	SideClient = errclass.SideClient
+	SideServer = errclass.SideServer
+
+	RetryableFalse   = errclass.RetryableFalse
+	RetryableTrue    = errclass.RetryableTrue
+	RetryableBackoff = errclass.RetryableBackoff
+)
+
+// NewConfig makes Config with calls, errors and latency metrics and applies opts
+func NewConfig(opts ...Option) Config {
+	return Config{
+		config: config.New(config.Unwrap(opts)...),
+	}
+}
+
+func WithoutLatency() Option {
+	return Option{
+		option: config.WithoutLatency(),
+	}
+}
+
+func WithoutCalls() Option {
+	return Option{
+		option: config.WithoutCalls(),
+	}
+}
+
+func WithoutError() Option {
+	return Option{
+		option: config.WithoutError(),
+	}
+}
+
+func WithValue(valueType ValueType) Option {
+	return Option{
+		option: config.WithValue(valueType),
+	}
+}
+
+func WithValueOnly(valueType ValueType) Option {
+	return Option{
+		option: config.WithValueOnly(valueType),
+	}
+}
+
+func WithValueBuckets(buckets []float64) Option {
+	return Option{
+		option: config.WithValueBuckets(buckets),
+	}
+}
+
+func WithValueUnit(unit registry.Unit) Option {
+	return Option{
+		option: config.WithValueUnit(unit),
+	}
+}
+
+func WithValueDescription(description string) Option {
+	return Option{
+		option: config.WithValueDescription(description),
+	}
+}
+
+// WithDescription sets human-readable description of scope
+func WithDescription(description string) Option {
+	return Option{
+		option: config.WithDescription(description),
+	}
+}
+
+func WithStability(stability registry.Stability) Option {
+	return Option{
+		option: config.WithStability(stability),
+	}
+}
+
+// WithDoneTags marks tags which values are known only on done of call
+func WithDoneTags(tags ...string) Option {
+	return Option{
+		option: config.WithDoneTags(tags...),
+	}
+}
+
+// WithStages marks scope as multi-stage with TagStage tag
+func WithStages() Option {
+	return Option{
+		option: config.WithStages(),
+	}
+}
+
+// WithContextLabels allows labels attached to context with metrics.WithLabels as scope tags
+func WithContextLabels(keys ...string) Option {
+	return Option{
+		option: config.WithContextLabels(keys...),
+	}
+}
+
+// WithoutTags drops tags from scope metrics
+func WithoutTags(tags ...string) Option {
+	return Option{
+		option: config.WithoutTags(tags...),
+	}
+}
+
+// WithAllowedTags drops tags of scope which not in allowlist
+func WithAllowedTags(tags ...string) Option {
+	return Option{
+		option: config.WithAllowedTags(tags...),
+	}
+}
+
+// WithConstLabels appends constant labels to all scope metrics
+func WithConstLabels(constLabels map[string]string) Option {
+	return Option{
+		option: config.WithConstLabels(constLabels),
+	}
+}
+
+// WithSamplingRate sets fraction of calls which latency is measured
+func WithSamplingRate(rate float64) Option {
+	return Option{
+		option: config.WithSamplingRate(rate),
+	}
+}
+
+// WithErrorClassifiers appends error classifiers which applies before builtin classifiers
+func WithErrorClassifiers(classifiers ...ErrorClassifier) Option {
+	return Option{
+		option: config.WithClassifiers(classifiers...),
+	}
+}
+
+// WithIssueCodes enables issueCode label of errors with most relevant issue code of operation errors
+// depth limits levels of issue tree, issue codes which not in allowed list labels as "other"
+func WithIssueCodes(depth int, allowed ...uint32) Option {
+	return Option{
+		option: config.WithIssueCodes(depth, allowed...),
+	}
+}
+
+// WithDebugSink sets sink of classified errors with raw error messages, such as network errors
+// with addresses of peers, which not used as labels
+func WithDebugSink(sink ErrorSink) Option {
+	return Option{
+		option: config.WithDebugSink(sink),
+	}
+}
+
+// Disabled disables all metrics of scope
+func Disabled() Option {
+	return Option{
+		option: config.Disabled(),
+	}
+}
+
+// Enabled enables scope which disabled by options of parent subsystem
+func Enabled() Option {
+	return Option{
+		option: config.Enabled(),
+	}
+}
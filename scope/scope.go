@@ -0,0 +1,50 @@
+// Package scope provides calls, errors, latency and value metrics of user-defined operations
+// with the same naming, labels and error classification as SDK metrics
+package scope
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/trace"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// Label is a tag with value of metrics
+type Label = labels.Label
+
+// Trace measures single call of scope
+// Sync methods finishes call, Intermediate syncs intermediate stage of multi-stage call
+type Trace = trace.Trace
+
+// Scope makes traces of calls
+type Scope interface {
+	// Start starts call with labels known at start of call
+	Start(lbls ...Label) Trace
+
+	// StartWithContext starts call with labels known at start of call
+	// Context used for exemplars and labels attached with metrics.WithLabels
+	StartWithContext(ctx context.Context, lbls ...Label) Trace
+}
+
+// New makes Scope with subsystem name and tags
+// All labels of Start and Sync methods must have tags from tags list
+func New(c registry.Config, name string, cfg Config, tags ...string) Scope {
+	return scope.New(c, name, cfg.unwrap(), tags...)
+}
+
+// Start starts call of scope s with labels known at start of call
+func Start(s Scope, lbls ...Label) Trace {
+	return s.Start(lbls...)
+}
+
+// Sync finishes call t with error and labels known on done of call
+func Sync(t Trace, err error, lbls ...Label) {
+	t.Sync(err, lbls...)
+}
+
+// SyncWithValue finishes call t with error, value and labels known on done of call
+func SyncWithValue(t Trace, err error, v float64, lbls ...Label) {
+	t.SyncWithValue(err, v, lbls...)
+}
@@ -14,17 +14,23 @@ func Scripting(c registry.Config) (t trace.Scripting) {
 		return t
 	}
 	c = c.WithSystem("scripting")
-	execute := scope.New(c, "execute", config.New())
-	explain := scope.New(c, "explain", config.New())
-	streamExecute := scope.New(c.WithSystem("stream"), "execute", config.New(), labels.TagStage)
+	execute := scope.New(c, "execute", config.New(config.WithDescription("scripting execute")))
+	explain := scope.New(c, "explain", config.New(config.WithDescription("scripting explain")))
+	streamExecute := scope.New(c.WithSystem("stream"), "execute", config.New(config.WithDescription("scripting stream execute"), config.WithStages()), labels.TagStage)
 	t.OnExecute = func(info trace.ScriptingExecuteStartInfo) func(trace.ScriptingExecuteDoneInfo) {
-		start := execute.Start()
+		if !execute.Enabled() {
+			return nil
+		}
+		start := execute.StartWithContext(contextOf(info.Context))
 		return func(info trace.ScriptingExecuteDoneInfo) {
 			start.Sync(info.Error)
 		}
 	}
 	t.OnExplain = func(info trace.ScriptingExplainStartInfo) func(trace.ScriptingExplainDoneInfo) {
-		start := explain.Start()
+		if !explain.Enabled() {
+			return nil
+		}
+		start := explain.StartWithContext(contextOf(info.Context))
 		return func(info trace.ScriptingExplainDoneInfo) {
 			start.Sync(info.Error)
 		}
@@ -36,16 +42,16 @@ func Scripting(c registry.Config) (t trace.Scripting) {
 	) func(
 		trace.ScriptingStreamExecuteDoneInfo,
 	) {
-		start := streamExecute.Start(labels.Label{
-			Tag:   labels.TagStage,
-			Value: "init",
-		})
+		if !streamExecute.Enabled() {
+			return nil
+		}
+		start := streamExecute.StartWithContext(contextOf(info.Context))
 		return func(
 			info trace.ScriptingStreamExecuteIntermediateInfo,
 		) func(
 			trace.ScriptingStreamExecuteDoneInfo,
 		) {
-			start.Sync(info.Error, labels.Label{
+			start.Intermediate(info.Error, labels.Label{
 				Tag:   labels.TagStage,
 				Value: "intermediate",
 			})
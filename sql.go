@@ -8,7 +8,8 @@ import (
 )
 
 // DatabaseSQL makes trace.DatabaseSQL with measuring `database/sql` events
-func DatabaseSQL(c registry.Config) (t trace.DatabaseSQL) {
+func DatabaseSQL(c registry.Config, opts ...Option) (t trace.DatabaseSQL) {
+	c = withOptions(c, opts...)
 	if c.Details()&trace.DatabaseSQLEvents == 0 {
 		return t
 	}
@@ -16,13 +17,16 @@ func DatabaseSQL(c registry.Config) (t trace.DatabaseSQL) {
 	if c.Details()&trace.DatabaseSQLConnectorEvents != 0 {
 		//nolint:govet
 		c := c.WithSystem("connector")
-		connect := scope.New(c, "connect", config.New())
+		connect := scope.New(c, "connect", config.New(config.WithDescription("database/sql connector connect")))
 		t.OnConnectorConnect = func(
 			info trace.DatabaseSQLConnectorConnectStartInfo,
 		) func(
 			trace.DatabaseSQLConnectorConnectDoneInfo,
 		) {
-			start := connect.Start()
+			if !connect.Enabled() {
+				return nil
+			}
+			start := connect.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnectorConnectDoneInfo) {
 				start.Sync(info.Error)
 			}
@@ -32,44 +36,62 @@ func DatabaseSQL(c registry.Config) (t trace.DatabaseSQL) {
 	if c.Details()&trace.DatabaseSQLConnEvents != 0 {
 		//nolint:govet
 		c := c.WithSystem("conn")
-		ping := scope.New(c, "ping", config.New())
-		close := scope.New(c, "close", config.New())
-		begin := scope.New(c, "begin", config.New())
-		prepare := scope.New(c, "prepare", config.New())
-		exec := scope.New(c, "exec", config.New())
-		query := scope.New(c, "query", config.New())
+		ping := scope.New(c, "ping", config.New(config.WithDescription("database/sql connection ping")))
+		close := scope.New(c, "close", config.New(config.WithDescription("database/sql connection close")))
+		begin := scope.New(c, "begin", config.New(config.WithDescription("database/sql connection begin transaction")))
+		prepare := scope.New(c, "prepare", config.New(config.WithDescription("database/sql connection prepare statement")))
+		exec := scope.New(c, "exec", config.New(config.WithDescription("database/sql connection exec")))
+		query := scope.New(c, "query", config.New(config.WithDescription("database/sql connection query")))
 		t.OnConnPing = func(info trace.DatabaseSQLConnPingStartInfo) func(trace.DatabaseSQLConnPingDoneInfo) {
-			start := ping.Start()
+			if !ping.Enabled() {
+				return nil
+			}
+			start := ping.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnPingDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnConnClose = func(info trace.DatabaseSQLConnCloseStartInfo) func(trace.DatabaseSQLConnCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
 			start := close.Start()
 			return func(info trace.DatabaseSQLConnCloseDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnConnBegin = func(info trace.DatabaseSQLConnBeginStartInfo) func(trace.DatabaseSQLConnBeginDoneInfo) {
-			start := begin.Start()
+			if !begin.Enabled() {
+				return nil
+			}
+			start := begin.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnBeginDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnConnPrepare = func(info trace.DatabaseSQLConnPrepareStartInfo) func(trace.DatabaseSQLConnPrepareDoneInfo) {
-			start := prepare.Start()
+			if !prepare.Enabled() {
+				return nil
+			}
+			start := prepare.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnPrepareDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnConnExec = func(info trace.DatabaseSQLConnExecStartInfo) func(trace.DatabaseSQLConnExecDoneInfo) {
-			start := exec.Start()
+			if !exec.Enabled() {
+				return nil
+			}
+			start := exec.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnExecDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnConnQuery = func(info trace.DatabaseSQLConnQueryStartInfo) func(trace.DatabaseSQLConnQueryDoneInfo) {
-			start := query.Start()
+			if !query.Enabled() {
+				return nil
+			}
+			start := query.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLConnQueryDoneInfo) {
 				start.Sync(info.Error)
 			}
@@ -78,30 +100,42 @@ func DatabaseSQL(c registry.Config) (t trace.DatabaseSQL) {
 	if c.Details()&trace.DatabaseSQLTxEvents != 0 {
 		//nolint:govet
 		c := c.WithSystem("tx")
-		commit := scope.New(c, "commit", config.New())
-		rollback := scope.New(c, "rollback", config.New())
-		query := scope.New(c, "query", config.New())
-		exec := scope.New(c, "exec", config.New())
+		commit := scope.New(c, "commit", config.New(config.WithDescription("database/sql transaction commit")))
+		rollback := scope.New(c, "rollback", config.New(config.WithDescription("database/sql transaction rollback")))
+		query := scope.New(c, "query", config.New(config.WithDescription("database/sql transaction query")))
+		exec := scope.New(c, "exec", config.New(config.WithDescription("database/sql transaction exec")))
 		t.OnTxCommit = func(info trace.DatabaseSQLTxCommitStartInfo) func(trace.DatabaseSQLTxCommitDoneInfo) {
-			start := commit.Start()
+			if !commit.Enabled() {
+				return nil
+			}
+			start := commit.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLTxCommitDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnTxRollback = func(info trace.DatabaseSQLTxRollbackStartInfo) func(trace.DatabaseSQLTxRollbackDoneInfo) {
-			start := rollback.Start()
+			if !rollback.Enabled() {
+				return nil
+			}
+			start := rollback.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLTxRollbackDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnTxQuery = func(info trace.DatabaseSQLTxQueryStartInfo) func(trace.DatabaseSQLTxQueryDoneInfo) {
-			start := query.Start()
+			if !query.Enabled() {
+				return nil
+			}
+			start := query.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLTxQueryDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnTxExec = func(info trace.DatabaseSQLTxExecStartInfo) func(trace.DatabaseSQLTxExecDoneInfo) {
-			start := exec.Start()
+			if !exec.Enabled() {
+				return nil
+			}
+			start := exec.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLTxExecDoneInfo) {
 				start.Sync(info.Error)
 			}
@@ -111,23 +145,32 @@ func DatabaseSQL(c registry.Config) (t trace.DatabaseSQL) {
 	if c.Details()&trace.DatabaseSQLStmtEvents != 0 {
 		//nolint:govet
 		c := c.WithSystem("stmt")
-		close := scope.New(c, "close", config.New())
-		exec := scope.New(c, "exec", config.New())
-		query := scope.New(c, "query", config.New())
+		close := scope.New(c, "close", config.New(config.WithDescription("database/sql statement close")))
+		exec := scope.New(c, "exec", config.New(config.WithDescription("database/sql statement exec")))
+		query := scope.New(c, "query", config.New(config.WithDescription("database/sql statement query")))
 		t.OnStmtClose = func(info trace.DatabaseSQLStmtCloseStartInfo) func(trace.DatabaseSQLStmtCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
 			start := close.Start()
 			return func(info trace.DatabaseSQLStmtCloseDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnStmtExec = func(info trace.DatabaseSQLStmtExecStartInfo) func(trace.DatabaseSQLStmtExecDoneInfo) {
-			start := exec.Start()
+			if !exec.Enabled() {
+				return nil
+			}
+			start := exec.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLStmtExecDoneInfo) {
 				start.Sync(info.Error)
 			}
 		}
 		t.OnStmtQuery = func(info trace.DatabaseSQLStmtQueryStartInfo) func(trace.DatabaseSQLStmtQueryDoneInfo) {
-			start := query.Start()
+			if !query.Enabled() {
+				return nil
+			}
+			start := query.StartWithContext(contextOf(info.Context))
 			return func(info trace.DatabaseSQLStmtQueryDoneInfo) {
 				start.Sync(info.Error)
 			}
@@ -19,20 +19,24 @@ func nodeID(sessionID string) string {
 	return u.Query().Get("node_id")
 }
 
-func Table(c registry.Config) (t trace.Table) {
-	c = c.WithSystem("table")
+// Table makes trace.Table with measuring table client events
+func Table(c registry.Config, opts ...Option) (t trace.Table) {
+	c = withOptions(c, opts...).WithSystem("table")
 	if c.Details()&trace.TableEvents != 0 {
 		createSession := scope.New(c, "createSession", config.New(
+			config.WithDescription("creating session"),
+			config.WithValueDescription("number of attempts"),
+			config.WithStages(),
 			config.WithValue(config.ValueTypeGauge)),
 			labels.TagStage,
 		)
 		t.OnCreateSession = func(info trace.TableCreateSessionStartInfo) func(info trace.TableCreateSessionIntermediateInfo) func(trace.TableCreateSessionDoneInfo) {
-			start := createSession.Start(labels.Label{
-				Tag:   labels.TagStage,
-				Value: "start",
-			})
+			if !createSession.Enabled() {
+				return nil
+			}
+			start := createSession.StartWithContext(contextOf(info.Context))
 			return func(info trace.TableCreateSessionIntermediateInfo) func(trace.TableCreateSessionDoneInfo) {
-				start.Sync(info.Error, labels.Label{
+				start.Intermediate(info.Error, labels.Label{
 					Tag:   labels.TagStage,
 					Value: "intermediate",
 				})
@@ -45,12 +49,18 @@ func Table(c registry.Config) (t trace.Table) {
 			}
 		}
 		do := scope.New(c, "do", config.New(
+			config.WithDescription("table.Do retry operation"),
+			config.WithValueDescription("number of attempts"),
+			config.WithStages(),
 			config.WithValue(config.ValueTypeHistogram),
 			config.WithValueBuckets([]float64{
 				1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 20, 50, 100, 200,
 			}),
 		), labels.TagIdempotent, labels.TagStage)
 		t.OnDo = func(info trace.TableDoStartInfo) func(info trace.TableDoIntermediateInfo) func(trace.TableDoDoneInfo) {
+			if !do.Enabled() {
+				return nil
+			}
 			idempotent := labels.Label{
 				Tag: labels.TagIdempotent,
 				Value: func() string {
@@ -60,12 +70,9 @@ func Table(c registry.Config) (t trace.Table) {
 					return "false"
 				}(),
 			}
-			start := do.Start(idempotent, labels.Label{
-				Tag:   labels.TagStage,
-				Value: "init",
-			})
+			start := do.StartWithContext(contextOf(info.Context), idempotent)
 			return func(info trace.TableDoIntermediateInfo) func(trace.TableDoDoneInfo) {
-				start.Sync(info.Error, idempotent, labels.Label{
+				start.Intermediate(info.Error, idempotent, labels.Label{
 					Tag:   labels.TagStage,
 					Value: "intermediate",
 				})
@@ -74,16 +81,23 @@ func Table(c registry.Config) (t trace.Table) {
 						Tag:   labels.TagStage,
 						Value: "finish",
 					})
+					do.ObserveAttempts(idempotent.Value, info.Attempts)
 				}
 			}
 		}
 		doTx := scope.New(c, "do_tx", config.New(
+			config.WithDescription("table.DoTx retry operation"),
+			config.WithValueDescription("number of attempts"),
+			config.WithStages(),
 			config.WithValue(config.ValueTypeHistogram),
 			config.WithValueBuckets([]float64{
 				1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 20, 50, 100, 200,
 			}),
 		), labels.TagIdempotent, labels.TagStage)
 		t.OnDoTx = func(info trace.TableDoTxStartInfo) func(info trace.TableDoTxIntermediateInfo) func(trace.TableDoTxDoneInfo) {
+			if !doTx.Enabled() {
+				return nil
+			}
 			idempotent := labels.Label{
 				Tag: labels.TagIdempotent,
 				Value: func() string {
@@ -93,12 +107,9 @@ func Table(c registry.Config) (t trace.Table) {
 					return "false"
 				}(),
 			}
-			start := doTx.Start(idempotent, labels.Label{
-				Tag:   labels.TagStage,
-				Value: "init",
-			})
+			start := doTx.StartWithContext(contextOf(info.Context), idempotent)
 			return func(info trace.TableDoTxIntermediateInfo) func(trace.TableDoTxDoneInfo) {
-				start.Sync(info.Error, idempotent, labels.Label{
+				start.Intermediate(info.Error, idempotent, labels.Label{
 					Tag:   labels.TagStage,
 					Value: "intermediate",
 				})
@@ -107,20 +118,29 @@ func Table(c registry.Config) (t trace.Table) {
 						Tag:   labels.TagStage,
 						Value: "finish",
 					})
+					doTx.ObserveAttempts(idempotent.Value, info.Attempts)
 				}
 			}
 		}
 		{
 			c := c.WithSystem("pool")
 			max := scope.New(c, "max", config.New(
+				config.WithDescription("session pool limit"),
+				config.WithValueDescription("max number of sessions"),
 				config.WithValueOnly(config.ValueTypeGauge)),
 			)
 			t.OnInit = func(info trace.TableInitStartInfo) func(trace.TableInitDoneInfo) {
+				if !max.Enabled() {
+					return nil
+				}
 				return func(info trace.TableInitDoneInfo) {
 					max.Start().SyncValue(float64(info.Limit))
 				}
 			}
 			t.OnClose = func(info trace.TableCloseStartInfo) func(trace.TableCloseDoneInfo) {
+				if !max.Enabled() {
+					return nil
+				}
 				return func(info trace.TableCloseDoneInfo) {
 					max.Start().SyncValue(0)
 				}
@@ -130,14 +150,14 @@ func Table(c registry.Config) (t trace.Table) {
 	if c.Details()&trace.TableSessionEvents != 0 {
 		c := c.WithSystem("session")
 		if c.Details()&trace.TableSessionLifeCycleEvents != 0 {
-			new := scope.New(c, "new", config.New(), labels.TagNodeID)
-			delete := scope.New(c, "delete", config.New(), labels.TagNodeID)
-			keepAlive := scope.New(c, "keep_alive", config.New(), labels.TagNodeID)
+			new := scope.New(c, "new", config.New(config.WithDescription("creating session on node"), config.WithDoneTags(labels.TagNodeID)), labels.TagNodeID)
+			delete := scope.New(c, "delete", config.New(config.WithDescription("deleting session")), labels.TagNodeID)
+			keepAlive := scope.New(c, "keep_alive", config.New(config.WithDescription("session keep-alive")), labels.TagNodeID)
 			t.OnSessionNew = func(info trace.TableSessionNewStartInfo) func(trace.TableSessionNewDoneInfo) {
-				start := new.Start(labels.Label{
-					Tag:   labels.TagNodeID,
-					Value: "wip",
-				})
+				if !new.Enabled() {
+					return nil
+				}
+				start := new.Start()
 				return func(info trace.TableSessionNewDoneInfo) {
 					nodeID := labels.Label{
 						Tag: labels.TagNodeID,
@@ -152,6 +172,9 @@ func Table(c registry.Config) (t trace.Table) {
 				}
 			}
 			t.OnSessionDelete = func(info trace.TableSessionDeleteStartInfo) func(trace.TableSessionDeleteDoneInfo) {
+				if !delete.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag:   labels.TagNodeID,
 					Value: nodeID(info.Session.ID()),
@@ -162,6 +185,9 @@ func Table(c registry.Config) (t trace.Table) {
 				}
 			}
 			t.OnSessionKeepAlive = func(info trace.TableKeepAliveStartInfo) func(trace.TableKeepAliveDoneInfo) {
+				if !keepAlive.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag:   labels.TagNodeID,
 					Value: nodeID(info.Session.ID()),
@@ -176,18 +202,21 @@ func Table(c registry.Config) (t trace.Table) {
 			c := c.WithSystem("query")
 			if c.Details()&trace.TableSessionQueryInvokeEvents != 0 {
 				c := c.WithSystem("invoke")
-				prepare := scope.New(c, "prepare", config.New(), labels.TagNodeID)
-				execute := scope.New(c, "execute", config.New(), labels.TagNodeID)
+				prepare := scope.New(c, "prepare", config.New(config.WithDescription("preparing data query")), labels.TagNodeID)
+				execute := scope.New(c, "execute", config.New(config.WithDescription("executing data query")), labels.TagNodeID)
 				t.OnSessionQueryPrepare = func(
 					info trace.TablePrepareDataQueryStartInfo,
 				) func(
 					trace.TablePrepareDataQueryDoneInfo,
 				) {
+					if !prepare.Enabled() {
+						return nil
+					}
 					nodeID := labels.Label{
 						Tag:   labels.TagNodeID,
 						Value: nodeID(info.Session.ID()),
 					}
-					start := prepare.Start(nodeID)
+					start := prepare.StartWithContext(contextOf(info.Context), nodeID)
 					return func(info trace.TablePrepareDataQueryDoneInfo) {
 						start.Sync(info.Error, nodeID)
 					}
@@ -197,11 +226,14 @@ func Table(c registry.Config) (t trace.Table) {
 				) func(
 					trace.TableExecuteDataQueryDoneInfo,
 				) {
+					if !execute.Enabled() {
+						return nil
+					}
 					nodeID := labels.Label{
 						Tag:   labels.TagNodeID,
 						Value: nodeID(info.Session.ID()),
 					}
-					start := execute.Start(nodeID)
+					start := execute.StartWithContext(contextOf(info.Context), nodeID)
 					return func(info trace.TableExecuteDataQueryDoneInfo) {
 						start.Sync(info.Error, nodeID)
 					}
@@ -209,8 +241,8 @@ func Table(c registry.Config) (t trace.Table) {
 			}
 			if c.Details()&trace.TableSessionQueryStreamEvents != 0 {
 				c := c.WithSystem("stream")
-				read := scope.New(c, "read", config.New(), labels.TagStage, labels.TagNodeID)
-				execute := scope.New(c, "execute", config.New(), labels.TagStage, labels.TagNodeID)
+				read := scope.New(c, "read", config.New(config.WithDescription("reading table by stream"), config.WithStages()), labels.TagStage, labels.TagNodeID)
+				execute := scope.New(c, "execute", config.New(config.WithDescription("executing scan query by stream"), config.WithStages()), labels.TagStage, labels.TagNodeID)
 				t.OnSessionQueryStreamExecute = func(
 					info trace.TableSessionQueryStreamExecuteStartInfo,
 				) func(
@@ -218,20 +250,20 @@ func Table(c registry.Config) (t trace.Table) {
 				) func(
 					trace.TableSessionQueryStreamExecuteDoneInfo,
 				) {
+					if !execute.Enabled() {
+						return nil
+					}
 					nodeID := labels.Label{
 						Tag:   labels.TagNodeID,
 						Value: nodeID(info.Session.ID()),
 					}
-					start := execute.Start(nodeID, labels.Label{
-						Tag:   labels.TagStage,
-						Value: "init",
-					})
+					start := execute.StartWithContext(contextOf(info.Context), nodeID)
 					return func(
 						info trace.TableSessionQueryStreamExecuteIntermediateInfo,
 					) func(
 						trace.TableSessionQueryStreamExecuteDoneInfo,
 					) {
-						start.Sync(info.Error, nodeID, labels.Label{
+						start.Intermediate(info.Error, nodeID, labels.Label{
 							Tag:   labels.TagStage,
 							Value: "intermediate",
 						})
@@ -250,20 +282,20 @@ func Table(c registry.Config) (t trace.Table) {
 				) func(
 					trace.TableSessionQueryStreamReadDoneInfo,
 				) {
+					if !read.Enabled() {
+						return nil
+					}
 					nodeID := labels.Label{
 						Tag:   labels.TagNodeID,
 						Value: nodeID(info.Session.ID()),
 					}
-					start := read.Start(nodeID, labels.Label{
-						Tag:   labels.TagStage,
-						Value: "init",
-					})
+					start := read.StartWithContext(contextOf(info.Context), nodeID)
 					return func(
 						info trace.TableSessionQueryStreamReadIntermediateInfo,
 					) func(
 						trace.TableSessionQueryStreamReadDoneInfo,
 					) {
-						start.Sync(info.Error, nodeID, labels.Label{
+						start.Intermediate(info.Error, nodeID, labels.Label{
 							Tag:   labels.TagStage,
 							Value: "intermediate",
 						})
@@ -279,35 +311,44 @@ func Table(c registry.Config) (t trace.Table) {
 		}
 		if c.Details()&trace.TableSessionTransactionEvents != 0 {
 			c := c.WithSystem("transaction")
-			begin := scope.New(c, "begin", config.New(), labels.TagNodeID)
-			commit := scope.New(c, "commit", config.New(), labels.TagNodeID)
-			rollback := scope.New(c, "rollback", config.New(), labels.TagNodeID)
+			begin := scope.New(c, "begin", config.New(config.WithDescription("beginning transaction")), labels.TagNodeID)
+			commit := scope.New(c, "commit", config.New(config.WithDescription("committing transaction")), labels.TagNodeID)
+			rollback := scope.New(c, "rollback", config.New(config.WithDescription("rolling back transaction")), labels.TagNodeID)
 			t.OnSessionTransactionBegin = func(info trace.TableSessionTransactionBeginStartInfo) func(trace.TableSessionTransactionBeginDoneInfo) {
+				if !begin.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag:   labels.TagNodeID,
 					Value: nodeID(info.Session.ID()),
 				}
-				start := begin.Start(nodeID)
+				start := begin.StartWithContext(contextOf(info.Context), nodeID)
 				return func(info trace.TableSessionTransactionBeginDoneInfo) {
 					start.Sync(info.Error, nodeID)
 				}
 			}
 			t.OnSessionTransactionCommit = func(info trace.TableSessionTransactionCommitStartInfo) func(trace.TableSessionTransactionCommitDoneInfo) {
+				if !commit.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag:   labels.TagNodeID,
 					Value: nodeID(info.Session.ID()),
 				}
-				start := commit.Start(nodeID)
+				start := commit.StartWithContext(contextOf(info.Context), nodeID)
 				return func(info trace.TableSessionTransactionCommitDoneInfo) {
 					start.Sync(info.Error, nodeID)
 				}
 			}
 			t.OnSessionTransactionRollback = func(info trace.TableSessionTransactionRollbackStartInfo) func(trace.TableSessionTransactionRollbackDoneInfo) {
+				if !rollback.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag:   labels.TagNodeID,
 					Value: nodeID(info.Session.ID()),
 				}
-				start := rollback.Start(nodeID)
+				start := rollback.StartWithContext(contextOf(info.Context), nodeID)
 				return func(info trace.TableSessionTransactionRollbackDoneInfo) {
 					start.Sync(info.Error, nodeID)
 				}
@@ -318,16 +359,21 @@ func Table(c registry.Config) (t trace.Table) {
 		c := c.WithSystem("pool")
 		if c.Details()&trace.TablePoolLifeCycleEvents != 0 {
 			size := scope.New(c, "size", config.New(
+				config.WithDescription("session pool size"),
+				config.WithValueDescription("number of sessions"),
 				config.WithValueOnly(config.ValueTypeGauge),
 			))
 			t.OnPoolStateChange = func(info trace.TablePoolStateChangeInfo) {
+				if !size.Enabled() {
+					return
+				}
 				size.Start().SyncValue(float64(info.Size))
 			}
 		}
 		if c.Details()&trace.TablePoolSessionLifeCycleEvents != 0 {
 			c := c.WithSystem("session")
-			add := scope.New(c, "add", config.New(config.WithoutError(), config.WithoutLatency()))
-			remove := scope.New(c, "remove", config.New(config.WithoutError(), config.WithoutLatency()))
+			add := scope.New(c, "add", config.New(config.WithDescription("adding session to pool"), config.WithoutError(), config.WithoutLatency()))
+			remove := scope.New(c, "remove", config.New(config.WithDescription("removing session from pool"), config.WithoutError(), config.WithoutLatency()))
 			t.OnPoolSessionAdd = func(info trace.TablePoolSessionAddInfo) {
 				add.AddCall(nil)
 			}
@@ -336,10 +382,17 @@ func Table(c registry.Config) (t trace.Table) {
 			}
 		}
 		if c.Details()&trace.TablePoolAPIEvents != 0 {
-			put := scope.New(c, "put", config.New(), labels.TagNodeID)
-			get := scope.New(c, "get", config.New(), labels.TagNodeID)
-			wait := scope.New(c, "wait", config.New(), labels.TagNodeID)
+			put := scope.New(c, "put", config.New(config.WithDescription("returning session to pool")), labels.TagNodeID)
+			get := scope.New(c, "get", config.New(
+				config.WithDescription("getting session from pool"),
+				config.WithValueDescription("number of attempts"),
+				config.WithDoneTags(labels.TagNodeID),
+			), labels.TagNodeID)
+			wait := scope.New(c, "wait", config.New(config.WithDescription("waiting session in pool"), config.WithDoneTags(labels.TagNodeID)), labels.TagNodeID)
 			t.OnPoolPut = func(info trace.TablePoolPutStartInfo) func(trace.TablePoolPutDoneInfo) {
+				if !put.Enabled() {
+					return nil
+				}
 				nodeID := labels.Label{
 					Tag: labels.TagNodeID,
 					Value: func() string {
@@ -355,34 +408,38 @@ func Table(c registry.Config) (t trace.Table) {
 				}
 			}
 			t.OnPoolGet = func(info trace.TablePoolGetStartInfo) func(trace.TablePoolGetDoneInfo) {
-				node := labels.Label{
-					Tag:   labels.TagNodeID,
-					Value: "wip",
+				if !get.Enabled() {
+					return nil
 				}
-				start := get.Start(node)
+				start := get.StartWithContext(contextOf(info.Context))
 				return func(info trace.TablePoolGetDoneInfo) {
-					node.Value = func() string {
-						if info.Session != nil {
-							return nodeID(info.Session.ID())
-						}
-						return ""
-					}()
+					node := labels.Label{
+						Tag: labels.TagNodeID,
+						Value: func() string {
+							if info.Session != nil {
+								return nodeID(info.Session.ID())
+							}
+							return ""
+						}(),
+					}
 					start.SyncWithValue(info.Error, float64(info.Attempts), node)
 				}
 			}
 			t.OnPoolWait = func(info trace.TablePoolWaitStartInfo) func(trace.TablePoolWaitDoneInfo) {
-				node := labels.Label{
-					Tag:   labels.TagNodeID,
-					Value: "wip",
+				if !wait.Enabled() {
+					return nil
 				}
-				start := wait.Start(node)
+				start := wait.StartWithContext(contextOf(info.Context))
 				return func(info trace.TablePoolWaitDoneInfo) {
-					node.Value = func() string {
-						if info.Session != nil {
-							return nodeID(info.Session.ID())
-						}
-						return "-"
-					}()
+					node := labels.Label{
+						Tag: labels.TagNodeID,
+						Value: func() string {
+							if info.Session != nil {
+								return nodeID(info.Session.ID())
+							}
+							return "-"
+						}(),
+					}
 					start.Sync(info.Error, node)
 				}
 			}
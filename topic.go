@@ -0,0 +1,351 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/bounded"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/labels"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/scope/config"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/internal/str"
+	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
+)
+
+// topicsLimit caps number of distinct topic label values, topics over limit labels as "other"
+const topicsLimit = 100
+
+var (
+	messagesBuckets = []float64{
+		0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000,
+	}
+	lagBuckets = []float64{
+		0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600,
+	}
+)
+
+// Topic makes trace.Topic with measuring topic reader events
+// SDK has no topic writer and no writer hooks of trace.Topic yet, so writer metrics are not provided
+// Read lag of messages not provided by trace.Topic, use TopicReadLag for observing read lag
+func Topic(c registry.Config, opts ...Option) (t trace.Topic) {
+	c = withOptions(c, opts...).WithSystem("topic").WithSystem("reader")
+	topics := bounded.New(topicsLimit)
+	topicLabel := func(name string) labels.Label {
+		return labels.Label{
+			Tag:   labels.TagTopic,
+			Value: topics.Value(name),
+		}
+	}
+	if c.Details()&trace.TopicReaderStreamLifeCycleEvents != 0 {
+		init := scope.New(c, "init", config.New(config.WithDescription("topic reader stream initialization")))
+		close := scope.New(c, "close", config.New(config.WithDescription("topic reader closing")))
+		reconnect := scope.New(c, "reconnect", config.New(config.WithDescription("topic reader reconnection")))
+		reconnectRequest := scope.New(c, "reconnect_request", config.New(
+			config.WithDescription("topic reader reconnect requests by reasons"),
+			config.WithoutLatency(),
+		))
+		t.OnReaderInit = func(info trace.TopicReaderInitStartInfo) func(trace.TopicReaderInitDoneInfo) {
+			if !init.Enabled() {
+				return nil
+			}
+			start := init.Start()
+			return func(info trace.TopicReaderInitDoneInfo) {
+				start.Sync(info.Error)
+			}
+		}
+		t.OnReaderClose = func(info trace.TopicReaderCloseStartInfo) func(trace.TopicReaderCloseDoneInfo) {
+			if !close.Enabled() {
+				return nil
+			}
+			start := close.Start()
+			return func(info trace.TopicReaderCloseDoneInfo) {
+				start.Sync(info.CloseError)
+			}
+		}
+		t.OnReaderReconnect = func(info trace.TopicReaderReconnectStartInfo) func(trace.TopicReaderReconnectDoneInfo) {
+			if !reconnect.Enabled() {
+				return nil
+			}
+			start := reconnect.Start()
+			return func(info trace.TopicReaderReconnectDoneInfo) {
+				start.Sync(info.Error)
+			}
+		}
+		t.OnReaderReconnectRequest = func(info trace.TopicReaderReconnectRequestInfo) {
+			if !reconnectRequest.Enabled() {
+				return
+			}
+			reconnectRequest.Start().Sync(info.Reason)
+		}
+	}
+	if c.Details()&trace.TopicReaderPartitionEvents != 0 {
+		c := c.WithSystem("partition")
+		partitionStart := scope.New(c, "start", config.New(config.WithDescription("starting partition session")), labels.TagTopic)
+		partitionStop := scope.New(c, "stop", config.New(config.WithDescription("stopping partition session")),
+			labels.TagTopic, labels.TagGraceful,
+		)
+		active := scope.New(c, "active", config.New(
+			config.WithDescription("active partition sessions"),
+			config.WithValueDescription("number of active partition sessions"),
+			config.WithValueOnly(config.ValueTypeGauge),
+		), labels.TagTopic)
+		sessions := newPartitionSessions()
+		t.OnReaderPartitionReadStartResponse = func(
+			info trace.TopicReaderPartitionReadStartResponseStartInfo,
+		) func(
+			trace.TopicReaderPartitionReadStartResponseDoneInfo,
+		) {
+			if !partitionStart.Enabled() && !active.Enabled() {
+				return nil
+			}
+			var (
+				topic        = topicLabel(info.Topic)
+				connectionID = info.ReaderConnectionID
+				sessionID    = info.PartitionSessionID
+			)
+			start := partitionStart.Start(topic)
+			return func(info trace.TopicReaderPartitionReadStartResponseDoneInfo) {
+				start.Sync(info.Error, topic)
+				if info.Error == nil {
+					active.Start().SyncValue(float64(sessions.start(connectionID, sessionID, topic.Value)), topic)
+				}
+			}
+		}
+		t.OnReaderPartitionReadStopResponse = func(
+			info trace.TopicReaderPartitionReadStopResponseStartInfo,
+		) func(
+			trace.TopicReaderPartitionReadStopResponseDoneInfo,
+		) {
+			if !partitionStop.Enabled() && !active.Enabled() {
+				return nil
+			}
+			var (
+				topic        = topicLabel(info.Topic)
+				connectionID = info.ReaderConnectionID
+				sessionID    = info.PartitionSessionID
+				graceful     = labels.Label{
+					Tag:   labels.TagGraceful,
+					Value: str.If(info.Graceful, "true", "false"),
+				}
+			)
+			start := partitionStop.Start(topic, graceful)
+			return func(info trace.TopicReaderPartitionReadStopResponseDoneInfo) {
+				start.Sync(info.Error, topic, graceful)
+				if info.Error == nil {
+					if count, ok := sessions.stop(connectionID, sessionID); ok {
+						active.Start().SyncValue(float64(count), topic)
+					}
+				}
+			}
+		}
+		// Stream of reader closes on reconnect and on close of reader without stop responses
+		// for its partition sessions, so sessions of closed stream forgets
+		onClose := t.OnReaderClose
+		t.OnReaderClose = func(info trace.TopicReaderCloseStartInfo) func(trace.TopicReaderCloseDoneInfo) {
+			for topic, count := range sessions.close(info.ReaderConnectionID) {
+				active.Start().SyncValue(float64(count), labels.Label{
+					Tag:   labels.TagTopic,
+					Value: topic,
+				})
+			}
+			if onClose == nil {
+				return nil
+			}
+			return onClose(info)
+		}
+	}
+	if c.Details()&trace.TopicReaderStreamEvents != 0 {
+		commit := scope.New(c, "commit", config.New(
+			config.WithDescription("committing offsets"),
+			config.WithValueDescription("number of committed offsets"),
+			config.WithValue(config.ValueTypeCounter),
+		), labels.TagTopic)
+		receive := scope.New(c, "receive", config.New(
+			config.WithDescription("receiving data responses"),
+			config.WithValueDescription("received bytes"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValue(config.ValueTypeCounter),
+		))
+		messages := scope.New(c.WithSystem("receive"), "messages", config.New(
+			config.WithDescription("received messages"),
+			config.WithValueDescription("number of received messages"),
+			config.WithValueOnly(config.ValueTypeCounter),
+		))
+		batches := scope.New(c.WithSystem("receive"), "batches", config.New(
+			config.WithDescription("received partition batches"),
+			config.WithValueDescription("number of received partition batches"),
+			config.WithValueOnly(config.ValueTypeCounter),
+		))
+		buffer := scope.New(c, "buffer", config.New(
+			config.WithDescription("local buffer of reader"),
+			config.WithValueDescription("size of local buffer after receive"),
+			config.WithValueUnit(registry.UnitBytes),
+			config.WithValueOnly(config.ValueTypeGauge),
+		))
+		t.OnReaderCommit = func(info trace.TopicReaderCommitStartInfo) func(trace.TopicReaderCommitDoneInfo) {
+			if !commit.Enabled() {
+				return nil
+			}
+			var (
+				topic   = topicLabel(info.Topic)
+				offsets = float64(info.EndOffset - info.StartOffset)
+				ctx     = info.RequestContext
+			)
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			start := commit.StartWithContext(ctx, topic)
+			return func(info trace.TopicReaderCommitDoneInfo) {
+				start.SyncWithValue(info.Error, offsets, topic)
+			}
+		}
+		t.OnReaderReceiveDataResponse = func(
+			info trace.TopicReaderReceiveDataResponseStartInfo,
+		) func(
+			trace.TopicReaderReceiveDataResponseDoneInfo,
+		) {
+			if !receive.Enabled() && !messages.Enabled() && !batches.Enabled() && !buffer.Enabled() {
+				return nil
+			}
+			var (
+				bytes         int
+				batchesCount  int
+				messagesCount int
+				bufferSize    = float64(info.LocalBufferSizeAfterReceive)
+				start         = receive.Start()
+			)
+			if info.DataResponse != nil {
+				bytes = info.DataResponse.GetBytesSize()
+				batchesCount, messagesCount = info.DataResponse.GetPartitionBatchMessagesCounts()
+			}
+			return func(info trace.TopicReaderReceiveDataResponseDoneInfo) {
+				start.SyncWithValue(info.Error, float64(bytes))
+				if info.Error == nil {
+					messages.Start().SyncValue(float64(messagesCount))
+					batches.Start().SyncValue(float64(batchesCount))
+				}
+				buffer.Start().SyncValue(bufferSize)
+			}
+		}
+	}
+	if c.Details()&trace.TopicReaderMessageEvents != 0 {
+		read := scope.New(c, "read", config.New(
+			config.WithDescription("reading messages batch"),
+			config.WithValueDescription("number of messages in batch"),
+			config.WithValue(config.ValueTypeHistogram),
+			config.WithValueBuckets(messagesBuckets),
+			config.WithDoneTags(labels.TagTopic),
+		), labels.TagTopic)
+		t.OnReaderReadMessages = func(info trace.TopicReaderReadMessagesStartInfo) func(trace.TopicReaderReadMessagesDoneInfo) {
+			if !read.Enabled() {
+				return nil
+			}
+			ctx := info.RequestContext
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			start := read.StartWithContext(ctx)
+			return func(info trace.TopicReaderReadMessagesDoneInfo) {
+				start.SyncWithValue(info.Error, float64(info.MessagesCount), topicLabel(info.Topic))
+			}
+		}
+	}
+	return t
+}
+
+// TopicReadLag makes observer of read lag: time between writing and reading of topic message
+// trace.Topic has no write time of messages, so reader code observes lag of each read message:
+//
+//	observe := metrics.TopicReadLag(c)
+//	msg, err := reader.ReadMessage(ctx)
+//	if err == nil {
+//		observe(msg.Topic(), msg.WrittenAt)
+//	}
+func TopicReadLag(c registry.Config, opts ...Option) func(topic string, writtenAt time.Time) {
+	c = withOptions(c, opts...).WithSystem("topic").WithSystem("reader")
+	if c.Details()&trace.TopicReaderMessageEvents == 0 {
+		return func(string, time.Time) {}
+	}
+	topics := bounded.New(topicsLimit)
+	lag := scope.New(c, "lag", config.New(
+		config.WithDescription("read lag of messages"),
+		config.WithValueDescription("time between writing and reading of message"),
+		config.WithValueUnit(registry.UnitSeconds),
+		config.WithValueBuckets(lagBuckets),
+		config.WithValueOnly(config.ValueTypeHistogram),
+	), labels.TagTopic)
+	return func(topic string, writtenAt time.Time) {
+		lag.Start().SyncValue(time.Since(writtenAt).Seconds(), labels.Label{
+			Tag:   labels.TagTopic,
+			Value: topics.Value(topic),
+		})
+	}
+}
+
+// partitionSessions counts active partition sessions by topics from start and stop responses of
+// reader streams. Each stream tracks own sessions, so closing of stream drops its sessions from counts
+type partitionSessions struct {
+	mu      sync.Mutex
+	streams map[string]map[int64]string
+	counts  map[string]int
+}
+
+func newPartitionSessions() *partitionSessions {
+	return &partitionSessions{
+		streams: make(map[string]map[int64]string),
+		counts:  make(map[string]int),
+	}
+}
+
+// start adds session of stream and returns number of active sessions of topic
+func (p *partitionSessions) start(connectionID string, sessionID int64, topic string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sessions, has := p.streams[connectionID]
+	if !has {
+		sessions = make(map[int64]string)
+		p.streams[connectionID] = sessions
+	}
+	if _, has := sessions[sessionID]; !has {
+		sessions[sessionID] = topic
+		p.counts[topic]++
+	}
+	return p.counts[topic]
+}
+
+// stop removes session of stream and returns number of active sessions of topic of session
+// stop reports false if session of stream is unknown
+func (p *partitionSessions) stop(connectionID string, sessionID int64) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	topic, has := p.streams[connectionID][sessionID]
+	if !has {
+		return 0, false
+	}
+	delete(p.streams[connectionID], sessionID)
+	if len(p.streams[connectionID]) == 0 {
+		delete(p.streams, connectionID)
+	}
+	p.counts[topic]--
+	return p.counts[topic], true
+}
+
+// close removes all sessions of stream and returns numbers of active sessions of affected topics
+func (p *partitionSessions) close(connectionID string) map[string]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sessions, has := p.streams[connectionID]
+	if !has {
+		return nil
+	}
+	delete(p.streams, connectionID)
+	counts := make(map[string]int, len(sessions))
+	for _, topic := range sessions {
+		p.counts[topic]--
+		counts[topic] = p.counts[topic]
+	}
+	return counts
+}
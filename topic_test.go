@@ -0,0 +1,32 @@
+package metrics
+
+import "testing"
+
+func TestPartitionSessions(t *testing.T) {
+	p := newPartitionSessions()
+	if count := p.start("a", 1, "topic"); count != 1 {
+		t.Fatalf("unexpected count: %d", count)
+	}
+	if count := p.start("a", 2, "topic"); count != 2 {
+		t.Fatalf("unexpected count: %d", count)
+	}
+	if count := p.start("b", 1, "topic"); count != 3 {
+		t.Fatalf("unexpected count: %d", count)
+	}
+	if count, ok := p.stop("a", 1); !ok || count != 2 {
+		t.Fatalf("unexpected stop: %d, %v", count, ok)
+	}
+	if _, ok := p.stop("a", 1); ok {
+		t.Fatal("stop of stopped session")
+	}
+	counts := p.close("b")
+	if len(counts) != 1 || counts["topic"] != 1 {
+		t.Fatalf("unexpected counts on close: %v", counts)
+	}
+	if counts := p.close("b"); counts != nil {
+		t.Fatalf("unexpected counts on second close: %v", counts)
+	}
+	if count := p.start("c", 1, "topic"); count != 2 {
+		t.Fatalf("unexpected count after reconnect: %d", count)
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk-metrics/registry"
 )
 
-func WithTraces(c registry.Config) ydb.Option {
+// WithTraces makes ydb.Option with all metrics traces allowed by details of c
+// Options applies to all traces
+func WithTraces(c registry.Config, opts ...Option) ydb.Option {
+	c = withOptions(c, opts...)
 	return ydb.MergeOptions(
 		ydb.WithTraceDriver(Driver(c)),
 		ydb.WithTraceTable(Table(c)),
@@ -16,5 +19,7 @@ func WithTraces(c registry.Config) ydb.Option {
 		ydb.WithTraceRatelimiter(Ratelimiter(c)),
 		ydb.WithTraceDiscovery(Discovery(c)),
 		ydb.WithTraceDatabaseSQL(DatabaseSQL(c)),
+		ydb.WithTraceRetry(Retry(c)),
+		ydb.WithTraceTopic(Topic(c)),
 	)
 }